@@ -0,0 +1,516 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/ethanthatonekid/gitcord/gitcord"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the format of the YAML configuration file. Any string value
+// may refer to environment variables as ${NAME}, which keeps tokens out of
+// the file itself.
+type fileConfig struct {
+	GitHub   fileGitHub                    `yaml:"github"`
+	Discord  fileDiscord                   `yaml:"discord"`
+	Server   fileServer                    `yaml:"server"`
+	Channels fileChannels                  `yaml:"channels"`
+	Refs     fileRefs                      `yaml:"refs"`
+	Store    storeSpec                     `yaml:"store"`
+	Routes   []fileRoute                   `yaml:"routes"`
+	Rules    []fileRule                    `yaml:"rules"`
+	Colors   map[colorKey]fileColors       `yaml:"colors"`
+	Events   map[eventName]bool            `yaml:"events"`
+	Threads  fileThreads                   `yaml:"threads"`
+	Commands map[commandAction][]snowflake `yaml:"commands"`
+}
+
+type fileGitHub struct {
+	Token         envString      `yaml:"token"`
+	Repo          envString      `yaml:"repo"`
+	WebhookSecret envString      `yaml:"webhook_secret"`
+	App           *fileGitHubApp `yaml:"app"`
+}
+
+type fileGitHubApp struct {
+	ID             int64     `yaml:"id"`
+	PrivateKey     envString `yaml:"private_key"`
+	PrivateKeyFile envString `yaml:"private_key_file"`
+	InstallationID int64     `yaml:"installation_id"`
+}
+
+type fileDiscord struct {
+	Token     envString `yaml:"token"`
+	ChannelID snowflake `yaml:"channel_id"`
+}
+
+type fileChannels struct {
+	Pushes            snowflake     `yaml:"pushes"`
+	Releases          snowflake     `yaml:"releases"`
+	CrosspostReleases bool          `yaml:"crosspost_releases"`
+	Deployments       snowflake     `yaml:"deployments"`
+	Refs              snowflake     `yaml:"refs"`
+	Security          snowflake     `yaml:"security"`
+	Activity          snowflake     `yaml:"activity"`
+	ActivityBatch     time.Duration `yaml:"activity_batch"`
+	Milestones        snowflake     `yaml:"milestones"`
+	Labels            snowflake     `yaml:"labels"`
+	Commits           snowflake     `yaml:"commits"`
+	Wiki              snowflake     `yaml:"wiki"`
+	WikiDiffs         bool          `yaml:"wiki_diffs"`
+}
+
+type fileRefs struct {
+	Branches []pattern `yaml:"branches"`
+	Tags     []pattern `yaml:"tags"`
+}
+
+type fileServer struct {
+	Addr    envString `yaml:"addr"`
+	Gateway bool      `yaml:"gateway"`
+}
+
+type fileRoute struct {
+	Repo      pattern   `yaml:"repo"`
+	ChannelID snowflake `yaml:"channel_id"`
+}
+
+type fileRule struct {
+	Match     fileRuleMatch `yaml:"match"`
+	Drop      bool          `yaml:"drop"`
+	ChannelID snowflake     `yaml:"channel_id"`
+	Mentions  []snowflake   `yaml:"mentions"`
+}
+
+type fileRuleMatch struct {
+	Events       []eventName `yaml:"events"`
+	Actions      []string    `yaml:"actions"`
+	Repos        []pattern   `yaml:"repos"`
+	Labels       []string    `yaml:"labels"`
+	Authors      []string    `yaml:"authors"`
+	Bot          *bool       `yaml:"bot"`
+	Draft        *bool       `yaml:"draft"`
+	BaseBranches []pattern   `yaml:"base_branches"`
+}
+
+type fileColors struct {
+	Success *color `yaml:"success"`
+	Error   *color `yaml:"error"`
+}
+
+type fileThreads struct {
+	ForceOpen       bool `yaml:"force_open"`
+	CreateForumTags bool `yaml:"create_forum_tags"`
+	DeferDrafts     bool `yaml:"defer_drafts"`
+}
+
+// loadConfigFile loads and validates the configuration file at path. Problems
+// are reported as a *configError.
+func loadConfigFile(path string) (*fileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read config file")
+	}
+
+	var cfg fileConfig
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&cfg); err != nil && err != io.EOF {
+		return nil, newConfigError(path, err)
+	}
+
+	if problems := cfg.validate(); len(problems) > 0 {
+		for i, problem := range problems {
+			problems[i] = path + ": " + problem
+		}
+		return nil, &configError{problems: problems}
+	}
+
+	return &cfg, nil
+}
+
+// validate reports problems that span more than one value.
+func (cfg *fileConfig) validate() []string {
+	var problems []string
+
+	if cfg.GitHub.Token == "" && cfg.GitHub.App == nil {
+		problems = append(problems, "one of github.token and github.app must be set")
+	}
+
+	if app := cfg.GitHub.App; app != nil {
+		if app.ID == 0 {
+			problems = append(problems, "github.app.id must be set")
+		}
+		if (app.PrivateKey == "") == (app.PrivateKeyFile == "") {
+			problems = append(problems, "exactly one of github.app.private_key and github.app.private_key_file must be set")
+		}
+	}
+
+	if cfg.Discord.Token == "" {
+		problems = append(problems, "discord.token must be set")
+	}
+
+	if cfg.Discord.ChannelID == 0 && len(cfg.Routes) == 0 {
+		problems = append(problems, "one of discord.channel_id and routes must be set")
+	}
+
+	return problems
+}
+
+// gitcordConfig converts the file into a gitcord.Config. The store is opened
+// by the caller.
+func (cfg *fileConfig) gitcordConfig() (gitcord.Config, error) {
+	config := gitcord.Config{
+		GitHubOAuth: oauth2.StaticTokenSource(&oauth2.Token{
+			AccessToken: string(cfg.GitHub.Token),
+		}),
+		GitHubRepo:       string(cfg.GitHub.Repo),
+		DiscordToken:     "Bot " + string(cfg.Discord.Token),
+		DiscordChannelID: discord.ChannelID(cfg.Discord.ChannelID),
+		Channels: gitcord.FeedChannels{
+			Pushes:            discord.ChannelID(cfg.Channels.Pushes),
+			Releases:          discord.ChannelID(cfg.Channels.Releases),
+			CrosspostReleases: cfg.Channels.CrosspostReleases,
+			Deployments:       discord.ChannelID(cfg.Channels.Deployments),
+			Refs:              discord.ChannelID(cfg.Channels.Refs),
+			Security:          discord.ChannelID(cfg.Channels.Security),
+			Activity:          discord.ChannelID(cfg.Channels.Activity),
+			ActivityBatch:     cfg.Channels.ActivityBatch,
+			Milestones:        discord.ChannelID(cfg.Channels.Milestones),
+			Labels:            discord.ChannelID(cfg.Channels.Labels),
+			Commits:           discord.ChannelID(cfg.Channels.Commits),
+			Wiki:              discord.ChannelID(cfg.Channels.Wiki),
+			WikiDiffs:         cfg.Channels.WikiDiffs,
+		},
+		CreateForumTags:   cfg.Threads.CreateForumTags,
+		ColorScheme:       gitcord.ColorScheme{},
+		ForceOpen:         cfg.Threads.ForceOpen,
+		DeferDraftThreads: cfg.Threads.DeferDrafts,
+	}
+
+	if app := cfg.GitHub.App; app != nil {
+		key := []byte(app.PrivateKey)
+		if app.PrivateKeyFile != "" {
+			var err error
+			key, err = os.ReadFile(string(app.PrivateKeyFile))
+			if err != nil {
+				return config, errors.Wrap(err, "failed to read GitHub App private key")
+			}
+		}
+
+		config.GitHubApp = &gitcord.GitHubAppConfig{
+			AppID:          app.ID,
+			PrivateKey:     key,
+			InstallationID: app.InstallationID,
+		}
+	}
+
+	for _, branch := range cfg.Refs.Branches {
+		config.Refs.Branches = append(config.Refs.Branches, string(branch))
+	}
+	for _, tag := range cfg.Refs.Tags {
+		config.Refs.Tags = append(config.Refs.Tags, string(tag))
+	}
+
+	for _, route := range cfg.Routes {
+		config.Routes = append(config.Routes, gitcord.Route{
+			Repo:      string(route.Repo),
+			ChannelID: discord.ChannelID(route.ChannelID),
+		})
+	}
+
+	// Disabled events are dropped by rules that come before all others.
+	for name, enabled := range cfg.Events {
+		if !enabled {
+			config.Rules = append(config.Rules, gitcord.Rule{
+				Match: gitcord.RuleMatch{Events: []string{string(name)}},
+				Drop:  true,
+			})
+		}
+	}
+
+	for _, rule := range cfg.Rules {
+		config.Rules = append(config.Rules, rule.rule())
+	}
+
+	for k, colors := range cfg.Colors {
+		status := gitcord.DefaultColorScheme[gitcord.ColorSchemeKey(k)]
+		if colors.Success != nil {
+			status.Success = discord.Color(*colors.Success)
+		}
+		if colors.Error != nil {
+			status.Error = discord.Color(*colors.Error)
+		}
+		config.ColorScheme[gitcord.ColorSchemeKey(k)] = status
+	}
+
+	if len(cfg.Commands) > 0 {
+		config.CommandPermissions = gitcord.CommandPermissions{}
+		for action, roles := range cfg.Commands {
+			for _, role := range roles {
+				a := gitcord.CommandAction(action)
+				config.CommandPermissions[a] = append(config.CommandPermissions[a], discord.RoleID(role))
+			}
+		}
+	}
+
+	return config, nil
+}
+
+func (r *fileRule) rule() gitcord.Rule {
+	rule := gitcord.Rule{
+		Match: gitcord.RuleMatch{
+			Actions: r.Match.Actions,
+			Labels:  r.Match.Labels,
+			Authors: r.Match.Authors,
+			Bot:     r.Match.Bot,
+			Draft:   r.Match.Draft,
+		},
+		Drop:      r.Drop,
+		ChannelID: discord.ChannelID(r.ChannelID),
+	}
+
+	for _, ev := range r.Match.Events {
+		rule.Match.Events = append(rule.Match.Events, string(ev))
+	}
+	for _, repo := range r.Match.Repos {
+		rule.Match.Repos = append(rule.Match.Repos, string(repo))
+	}
+	for _, branch := range r.Match.BaseBranches {
+		rule.Match.BaseBranches = append(rule.Match.BaseBranches, string(branch))
+	}
+	for _, role := range r.Mentions {
+		rule.Mentions = append(rule.Mentions, discord.RoleID(role))
+	}
+
+	return rule
+}
+
+// configError reports every problem found in a configuration file, each
+// prefixed by the file's path and, if known, the line number.
+type configError struct {
+	problems []string
+}
+
+var (
+	yamlLineRe         = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+	yamlUnknownFieldRe = regexp.MustCompile(`field (\S+) not found in type \S+`)
+)
+
+// newConfigError converts an error returned by the YAML decoder into a
+// configError whose problems are prefixed by their line numbers.
+func newConfigError(path string, err error) *configError {
+	var problems []string
+
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		problems = typeErr.Errors
+	} else {
+		problems = []string{err.Error()}
+	}
+
+	for i, problem := range problems {
+		problem = yamlUnknownFieldRe.ReplaceAllString(problem, "unknown key $1")
+		if yamlLineRe.MatchString(problem) {
+			problems[i] = path + ":" + yamlLineRe.ReplaceAllString(problem, "$1: ")
+		} else {
+			problems[i] = path + ": " + problem
+		}
+	}
+
+	return &configError{problems: problems}
+}
+
+func (err *configError) Error() string {
+	return "invalid config file:\n" + strings.Join(err.problems, "\n")
+}
+
+// valueError returns an error about the value of node that is collected by
+// the YAML decoder along with every other such error.
+func valueError(node *yaml.Node, f string, v ...any) error {
+	return &yaml.TypeError{Errors: []string{
+		fmt.Sprintf("line %d: ", node.Line) + fmt.Sprintf(f, v...),
+	}}
+}
+
+var envRefRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// scalarValue returns the value of the scalar node with references to
+// environment variables expanded.
+func scalarValue(node *yaml.Node) (string, error) {
+	if node.Kind != yaml.ScalarNode {
+		return "", valueError(node, "expected a single value")
+	}
+
+	var missing string
+	value := envRefRe.ReplaceAllStringFunc(node.Value, func(ref string) string {
+		name := envRefRe.FindStringSubmatch(ref)[1]
+		v, ok := os.LookupEnv(name)
+		if !ok && missing == "" {
+			missing = name
+		}
+		return v
+	})
+
+	if missing != "" {
+		return "", valueError(node, "environment variable $%s is not set", missing)
+	}
+
+	return value, nil
+}
+
+// envString is a string that may refer to environment variables.
+type envString string
+
+func (s *envString) UnmarshalYAML(node *yaml.Node) error {
+	v, err := scalarValue(node)
+	if err != nil {
+		return err
+	}
+	*s = envString(v)
+	return nil
+}
+
+// snowflake is a Discord ID.
+type snowflake discord.Snowflake
+
+func (s *snowflake) UnmarshalYAML(node *yaml.Node) error {
+	v, err := scalarValue(node)
+	if err != nil {
+		return err
+	}
+
+	id, err := discord.ParseSnowflake(v)
+	if err != nil {
+		return valueError(node, "invalid Discord ID %q", v)
+	}
+
+	*s = snowflake(id)
+	return nil
+}
+
+// pattern is a pattern in the syntax of path.Match.
+type pattern string
+
+func (p *pattern) UnmarshalYAML(node *yaml.Node) error {
+	v, err := scalarValue(node)
+	if err != nil {
+		return err
+	}
+
+	if _, err := path.Match(v, ""); err != nil {
+		return valueError(node, "invalid pattern %q", v)
+	}
+
+	*p = pattern(v)
+	return nil
+}
+
+// color is a color in the form of #RRGGBB.
+type color discord.Color
+
+func (c *color) UnmarshalYAML(node *yaml.Node) error {
+	v, err := scalarValue(node)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := parseColor(v)
+	if err != nil {
+		return valueError(node, "invalid color %q, must be of format #RRGGBB", v)
+	}
+
+	*c = color(parsed)
+	return nil
+}
+
+// colorKey is the snake_case name of a gitcord.ColorSchemeKey.
+type colorKey gitcord.ColorSchemeKey
+
+func (k *colorKey) UnmarshalYAML(node *yaml.Node) error {
+	key, ok := gitcord.ParseColorSchemeKey(node.Value)
+	if !ok {
+		return valueError(node, "unknown color %q", node.Value)
+	}
+	*k = colorKey(key)
+	return nil
+}
+
+// eventName is a webhook event name like "pull_request" or an event type like
+// "PullRequestEvent".
+type eventName string
+
+func (n *eventName) UnmarshalYAML(node *yaml.Node) error {
+	evType := node.Value
+	if !strings.HasSuffix(evType, "Event") {
+		evType = gitcord.EventType(evType)
+	}
+
+	for _, known := range gitcord.EventTypes {
+		if evType == known {
+			*n = eventName(evType)
+			return nil
+		}
+	}
+
+	return valueError(node, "unknown event %q", node.Value)
+}
+
+// commandAction is a gitcord.CommandAction.
+type commandAction gitcord.CommandAction
+
+func (a *commandAction) UnmarshalYAML(node *yaml.Node) error {
+	switch action := gitcord.CommandAction(node.Value); action {
+	case gitcord.CloseAction, gitcord.ReopenAction, gitcord.LabelAction, gitcord.AssignAction,
+		gitcord.RequestReviewAction, gitcord.LockAction, gitcord.AnyAction:
+		*a = commandAction(action)
+		return nil
+	default:
+		return valueError(node, "unknown command %q", node.Value)
+	}
+}
+
+// storeSpec is a store as accepted by openStore.
+type storeSpec string
+
+func (s *storeSpec) UnmarshalYAML(node *yaml.Node) error {
+	v, err := scalarValue(node)
+	if err != nil {
+		return err
+	}
+
+	kind, path, ok := strings.Cut(v, ":")
+	if !ok || path == "" || (kind != "json" && kind != "bolt") {
+		return valueError(node, "invalid store %q, must be json:PATH or bolt:PATH", v)
+	}
+
+	*s = storeSpec(v)
+	return nil
+}
+
+// parseColor parses a color in the form of #RRGGBB.
+func parseColor(s string) (discord.Color, error) {
+	if !strings.HasPrefix(s, "#") || len(s) != 7 {
+		return 0, errors.New("color must be of format #RRGGBB")
+	}
+
+	c, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return discord.Color(c), nil
+}
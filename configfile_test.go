@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethanthatonekid/gitcord/gitcord"
+)
+
+func TestLoadConfigFile(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	t.Setenv("DISCORD_TOKEN", "discord-token")
+	t.Setenv("GITHUB_WEBHOOK_SECRET", "secret")
+
+	cfg, err := loadConfigFile("gitcord.example.yaml")
+	if err != nil {
+		t.Fatal("failed to load example config:", err)
+	}
+
+	config, err := cfg.gitcordConfig()
+	if err != nil {
+		t.Fatal("failed to convert example config:", err)
+	}
+
+	if config.DiscordToken != "Bot discord-token" {
+		t.Errorf("unexpected Discord token %q", config.DiscordToken)
+	}
+
+	if string(cfg.GitHub.WebhookSecret) != "secret" {
+		t.Errorf("unexpected webhook secret %q", cfg.GitHub.WebhookSecret)
+	}
+
+	if len(config.Rules) != 3 || !config.Rules[0].Drop || config.Rules[0].Match.Events[0] != "PullRequestReviewThreadEvent" {
+		t.Errorf("unexpected rules %+v", config.Rules)
+	}
+
+	if config.Channels.ActivityBatch != 10*time.Minute {
+		t.Errorf("unexpected activity batch %v", config.Channels.ActivityBatch)
+	}
+
+	if c := config.ColorScheme.Color(gitcord.IssueOpened, true); c != 0x2EA043 {
+		t.Errorf("unexpected issue_opened color %06X", c)
+	}
+
+	if c := config.ColorScheme.Color(gitcord.SecurityCritical, true); c != 0x8B0000 {
+		t.Errorf("unexpected default security_critical color %06X", c)
+	}
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	const bad = `github:
+  token: ${GITCORD_TEST_UNSET}
+  tokn: oops
+discord:
+  token: abc
+  channel_id: notanumber
+colors:
+  issue_opened:
+    error: red
+  issue_bogus:
+    success: "#000000"
+events:
+  pushy: true
+store: sqlite:gitcord.db
+`
+
+	path := filepath.Join(t.TempDir(), "gitcord.yaml")
+	if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := loadConfigFile(path)
+
+	var cfgErr *configError
+	if !errors.As(err, &cfgErr) {
+		t.Fatalf("expected configError, got %v", err)
+	}
+
+	expected := []string{
+		path + ":2: environment variable $GITCORD_TEST_UNSET is not set",
+		path + ":3: unknown key tokn",
+		path + ":6: invalid Discord ID \"notanumber\"",
+		path + ":9: invalid color \"red\", must be of format #RRGGBB",
+		path + ":10: unknown color \"issue_bogus\"",
+		path + ":13: unknown event \"pushy\"",
+		path + ":14: invalid store \"sqlite:gitcord.db\", must be json:PATH or bolt:PATH",
+	}
+
+	if !reflect.DeepEqual(cfgErr.problems, expected) {
+		t.Errorf("unexpected problems:\n%q\nwant:\n%q", cfgErr.problems, expected)
+	}
+}
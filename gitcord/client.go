@@ -3,12 +3,17 @@ package gitcord
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/ethanthatonekid/gitcord/gitcord/internal/discordclient"
 	"github.com/ethanthatonekid/gitcord/gitcord/internal/githubclient"
+	"github.com/ethanthatonekid/gitcord/gitcord/internal/slices"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
 
 	"github.com/google/go-github/v47/github"
 )
@@ -16,18 +21,41 @@ import (
 type client struct {
 	github  *githubclient.Client
 	discord *discordclient.Client
+	store   store.Store
 	logger  *log.Logger
 	config  Config
+	// activity is shared by all copies of the client.
+	activity *activityBatches
+	// ruleChannel is the channel that the rule matching the event routes
+	// it into, if any.
+	ruleChannel discord.ChannelID
 }
 
 // Client is the GitHub-Discord bot.
 type Client struct {
-	Issues         *IssuesClient
-	Comments       *IssueCommentClient
-	PRs            *PRsClient
-	Reviews        *ReviewsClient
-	ReviewComments *ReviewCommentsClient
-	ReviewThreads  *ReviewThreadsClient
+	Issues             *IssuesClient
+	Comments           *IssueCommentClient
+	PRs                *PRsClient
+	Reviews            *ReviewsClient
+	ReviewComments     *ReviewCommentsClient
+	ReviewThreads      *ReviewThreadsClient
+	Replies            *RepliesClient
+	Commands           *CommandsClient
+	Pushes             *PushesClient
+	Releases           *ReleasesClient
+	Discussions        *DiscussionsClient
+	DiscussionComments *DiscussionCommentsClient
+	Checks             *ChecksClient
+	Statuses           *StatusesClient
+	Deployments        *DeploymentsClient
+	Refs               *RefsClient
+	Security           *SecurityClient
+	Activity           *ActivityClient
+	Milestones         *MilestonesClient
+	Labels             *LabelsClient
+	CommitComments     *CommitCommentsClient
+	Wiki               *WikiClient
+	Projects           *ProjectsClient
 
 	client *client
 }
@@ -45,39 +73,243 @@ func NewClient(cfg Config) *Client {
 // wrapClient wraps the internal client
 func wrapClient(c *client) *Client {
 	return &Client{
-		Issues:         (*IssuesClient)(c),
-		Comments:       (*IssueCommentClient)(c),
-		PRs:            (*PRsClient)(c),
-		Reviews:        (*ReviewsClient)(c),
-		ReviewComments: (*ReviewCommentsClient)(c),
-		ReviewThreads:  (*ReviewThreadsClient)(c),
+		Issues:             (*IssuesClient)(c),
+		Comments:           (*IssueCommentClient)(c),
+		PRs:                (*PRsClient)(c),
+		Reviews:            (*ReviewsClient)(c),
+		ReviewComments:     (*ReviewCommentsClient)(c),
+		ReviewThreads:      (*ReviewThreadsClient)(c),
+		Replies:            (*RepliesClient)(c),
+		Commands:           (*CommandsClient)(c),
+		Pushes:             (*PushesClient)(c),
+		Releases:           (*ReleasesClient)(c),
+		Discussions:        (*DiscussionsClient)(c),
+		DiscussionComments: (*DiscussionCommentsClient)(c),
+		Checks:             (*ChecksClient)(c),
+		Statuses:           (*StatusesClient)(c),
+		Deployments:        (*DeploymentsClient)(c),
+		Refs:               (*RefsClient)(c),
+		Security:           (*SecurityClient)(c),
+		Activity:           (*ActivityClient)(c),
+		Milestones:         (*MilestonesClient)(c),
+		Labels:             (*LabelsClient)(c),
+		CommitComments:     (*CommitCommentsClient)(c),
+		Wiki:               (*WikiClient)(c),
+		Projects:           (*ProjectsClient)(c),
 
 		client: c,
 	}
 }
 
 func newClient(cfg Config) *client {
+	if cfg.Logger == nil {
+		cfg.Logger = log.Default()
+	}
+
+	if cfg.Store == nil {
+		cfg.Store = store.NewMemory()
+	}
+
+	ghcfg := githubclient.Config{
+		OAuth:  cfg.GitHubOAuth,
+		Repo:   cfg.GitHubRepo,
+		Logger: cfg.Logger,
+	}
+	if cfg.GitHubApp != nil {
+		ghcfg.App = &githubclient.AppConfig{
+			AppID:          cfg.GitHubApp.AppID,
+			PrivateKey:     cfg.GitHubApp.PrivateKey,
+			InstallationID: cfg.GitHubApp.InstallationID,
+		}
+	}
+
 	return &client{
-		github: githubclient.New(githubclient.Config{
-			OAuth:  cfg.GitHubOAuth,
-			Logger: cfg.Logger,
-		}),
+		github: githubclient.New(ghcfg),
 		discord: discordclient.New(discordclient.Config{
-			Token:     cfg.DiscordToken,
-			ChannelID: cfg.DiscordChannelID,
-			Logger:    cfg.Logger,
+			Token:         cfg.DiscordToken,
+			ChannelID:     cfg.DiscordChannelID,
+			Store:         cfg.Store,
+			Logger:        cfg.Logger,
+			SharedChannel: cfg.sharedChannel,
 		}),
-		logger: cfg.Logger,
-		config: cfg,
+		store:    cfg.Store,
+		logger:   cfg.Logger,
+		config:   cfg,
+		activity: newActivityBatches(),
 	}
 }
 
 func (c *client) WithContext(ctx context.Context) *client {
-	return &client{
-		github:  c.github.WithContext(ctx),
-		discord: c.discord.WithContext(ctx),
-		config:  c.config,
+	cpy := *c
+	cpy.github = c.github.WithContext(ctx)
+	cpy.discord = c.discord.WithContext(ctx)
+	return &cpy
+}
+
+// withGitHub returns a copy of c using the given GitHub client.
+func (c *client) withGitHub(gh *githubclient.Client) *client {
+	cpy := *c
+	cpy.github = gh
+	return &cpy
+}
+
+// forChannel returns a copy of c that opens and finds threads under the given
+// parent channel.
+func (c *client) forChannel(ch discord.ChannelID) *client {
+	cpy := *c
+	cpy.discord = c.discord.ForChannel(ch)
+	return &cpy
+}
+
+// withRuleChannel returns a copy of c whose feeds are routed into ch.
+func (c *client) withRuleChannel(ch discord.ChannelID) *client {
+	cpy := *c
+	cpy.ruleChannel = ch
+	return &cpy
+}
+
+// feedChannel returns the channel that events not belonging to a thread are
+// posted into: the channel of the matching rule, if any, or else ch.
+func (c *client) feedChannel(ch discord.ChannelID) discord.ChannelID {
+	if c.ruleChannel.IsValid() {
+		return c.ruleChannel
+	}
+	return ch
+}
+
+// withThreadKind returns a copy of c that records the threads it opens and
+// finds as kind.
+func (c *client) withThreadKind(kind store.ThreadKind) *client {
+	cpy := *c
+	cpy.discord = c.discord.WithThreadKind(kind)
+	return &cpy
+}
+
+// isDiscussion returns true if the thread of k belongs to a discussion rather
+// than to an issue or pull request, so the issues API can't act on it.
+func (c *client) isDiscussion(k store.Key) bool {
+	t, err := c.store.Thread(k)
+	return err == nil && t.Kind == store.DiscussionThread
+}
+
+// withMentions returns a copy of c that mentions the given roles in the
+// messages it sends.
+func (c *client) withMentions(roles []discord.RoleID) *client {
+	cpy := *c
+	cpy.discord = c.discord.WithMentions(roles...)
+	return &cpy
+}
+
+// maxThreadNameLen is the maximum length of a Discord thread name.
+const maxThreadNameLen = 100
+
+// threadName returns the name of the thread of the issue or pull request k.
+// Threads in channels shared by several repositories are prefixed with the
+// repository so that issues with the same number can be told apart.
+func (c *client) threadName(k store.Key, title string) string {
+	name := fmt.Sprintf("%d: %s", k.Number, title)
+	if c.config.sharedChannel(c.discord.ChannelID()) {
+		name = fmt.Sprintf("%s#%d: %s", k.Repo, k.Number, title)
+	}
+
+	if runes := []rune(name); len(runes) > maxThreadNameLen {
+		name = string(runes[:maxThreadNameLen-1]) + "…"
+	}
+
+	return name
+}
+
+// threadKey returns the store key of an issue or pull request in repo.
+func threadKey(repo *github.Repository, number int) store.Key {
+	return store.Key{Repo: repo.GetFullName(), Number: number}
+}
+
+// editInitialEmbed edits the initial message msgID of thread ch to show embed,
+// keeping its Project status field, which only project events know.
+func (c *client) editInitialEmbed(ch discord.ChannelID, msgID discord.MessageID, embed discord.Embed) error {
+	if old, err := c.discord.Message(ch, msgID); err == nil && len(old.Embeds) > 0 {
+		status := slices.Find(old.Embeds[0].Fields, func(f *discord.EmbedField) bool {
+			return f.Name == projectStatusName
+		})
+		embed.Fields = withProjectStatus(embed.Fields, status)
 	}
+
+	_, err := c.discord.EditEmbeds(ch, msgID, embed)
+	return err
+}
+
+// labelNames returns the names of the given labels.
+func labelNames(labels []*github.Label) []string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.GetName()
+	}
+	return names
+}
+
+// prThread is the thread of a pull request.
+type prThread struct {
+	id discord.ChannelID
+	pr *github.PullRequest
+}
+
+// headThreads returns the existing threads of the pull requests whose head is
+// sha. prs are the pull requests given by the event, which GitHub leaves
+// empty for pull requests from forks; those are looked up by the commit
+// instead.
+func (c *client) headThreads(repo *github.Repository, sha string, prs []*github.PullRequest) []prThread {
+	if len(prs) == 0 {
+		var err error
+		prs, err = c.commitPRs(repo, sha)
+		if err != nil {
+			c.logger.Println("failed to find pull requests of", shortSHA(sha)+":", err)
+			return nil
+		}
+	}
+
+	var threads []prThread
+	for _, pr := range prs {
+		// Skip the results of commits that are no longer the head.
+		if pr.GetHead().GetSHA() != sha {
+			continue
+		}
+
+		t, err := c.discord.ExistingThread(threadKey(repo, pr.GetNumber()))
+		if err != nil {
+			continue
+		}
+
+		threads = append(threads, prThread{t.ID, pr})
+	}
+
+	return threads
+}
+
+// commitPRs returns the open pull requests of repo that contain the commit
+// sha.
+func (c *client) commitPRs(repo *github.Repository, sha string) ([]*github.PullRequest, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	prs, _, err := c.github.PullRequests.ListPullRequestsWithCommit(c.github.Context(), owner, name, sha, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.FilterReuse(prs, func(pr **github.PullRequest) bool {
+		return (*pr).GetState() == "open"
+	}), nil
+}
+
+// splitRepo splits the full name of repo into its owner and name.
+func splitRepo(repo *github.Repository) (owner, name string, err error) {
+	owner, name, ok := strings.Cut(repo.GetFullName(), "/")
+	if !ok {
+		return "", "", fmt.Errorf("invalid repository %q", repo.GetFullName())
+	}
+	return owner, name, nil
 }
 
 // DoEventID handles a GitHub event by ID.
@@ -92,6 +324,19 @@ func (c *Client) DoEventID(id int64) error {
 	return c.DoEvent(ev)
 }
 
+var pascalFromSnakeRe = regexp.MustCompile(`(?m)(^|_)[a-z]`)
+
+// EventType converts a snake_case webhook event name, as found in the
+// X-GitHub-Event header or $GITHUB_EVENT_NAME, into the event type used by
+// DoEvent, e.g. "pull_request" becomes "PullRequestEvent".
+func EventType(name string) string {
+	return pascalFromSnakeRe.ReplaceAllStringFunc(name, func(s string) string {
+		return strings.ToUpper(strings.TrimPrefix(s, "_"))
+	}) + "Event"
+}
+
+// DoEventPayload handles a GitHub event of the given type from its raw JSON
+// payload.
 func (c *Client) DoEventPayload(name, plStr string) error {
 	pl := []byte(plStr)
 	return c.DoEvent(&github.Event{
@@ -100,9 +345,68 @@ func (c *Client) DoEventPayload(name, plStr string) error {
 	})
 }
 
+// EventTypes are the event types handled by DoEvent.
+var EventTypes = []string{
+	"IssuesEvent",
+	"IssueCommentEvent",
+	"PullRequestEvent",
+	"PullRequestReviewEvent",
+	"PullRequestReviewCommentEvent",
+	"PullRequestReviewThreadEvent",
+	"PushEvent",
+	"ReleaseEvent",
+	"DiscussionEvent",
+	"DiscussionCommentEvent",
+	"WorkflowRunEvent",
+	"CheckSuiteEvent",
+	"CheckRunEvent",
+	"StatusEvent",
+	"DeploymentEvent",
+	"DeploymentStatusEvent",
+	"CreateEvent",
+	"DeleteEvent",
+	"DependabotAlertEvent",
+	"CodeScanningAlertEvent",
+	"SecretScanningAlertEvent",
+	"SecurityAdvisoryEvent",
+	"WatchEvent",
+	"ForkEvent",
+	"MemberEvent",
+	"PublicEvent",
+	"SponsorshipEvent",
+	"MilestoneEvent",
+	"LabelEvent",
+	"CommitCommentEvent",
+	"GollumEvent",
+	"ProjectsV2ItemEvent",
+	"ProjectCardEvent",
+	"MergeGroupEvent",
+}
+
 // DoEvent handles a GitHub event.
 func (c *Client) DoEvent(ev *github.Event) error {
-	data, err := ev.ParsePayload()
+	data, err := parsePayload(ev)
+	if err != nil {
+		return err
+	}
+
+	// When authenticating as a GitHub App, act as the installation that the
+	// event was delivered for.
+	gh, err := c.client.github.ForPayload(*ev.RawPayload)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate event %q: %w", *ev.Type, err)
+	}
+	if gh != c.client.github {
+		c = wrapClient(c.client.withGitHub(gh))
+	}
+
+	rule := c.client.config.Rules.Find(*ev.Type, data)
+	if rule != nil && rule.Drop {
+		c.client.logger.Println("rules: dropped event", *ev.Type)
+		return nil
+	}
+
+	c, err = c.route(*ev.Type, data, rule)
 	if err != nil {
 		return err
 	}
@@ -113,13 +417,69 @@ func (c *Client) DoEvent(ev *github.Event) error {
 	case "IssueCommentEvent":
 		err = c.handleIssueCommentEvent(data.(*github.IssueCommentEvent))
 	case "PullRequestEvent":
-		err = c.handlePREvent(data.(*github.PullRequestEvent))
+		err = c.handlePREvent(data.(*PullRequestEvent))
 	case "PullRequestReviewEvent":
 		err = c.handlePullRequestReviewEvent(data.(*github.PullRequestReviewEvent))
 	case "PullRequestReviewCommentEvent":
 		err = c.handlePullRequestReviewCommentEvent(data.(*github.PullRequestReviewCommentEvent))
 	case "PullRequestReviewThreadEvent":
 		err = c.handlePullRequestReviewThreadEvent(data.(*github.PullRequestReviewThreadEvent))
+	case "PushEvent":
+		err = c.handlePushEvent(data.(*github.PushEvent))
+	case "ReleaseEvent":
+		err = c.handleReleaseEvent(data.(*github.ReleaseEvent))
+	case "DiscussionEvent":
+		err = c.handleDiscussionEvent(data.(*DiscussionEvent))
+	case "DiscussionCommentEvent":
+		err = c.handleDiscussionCommentEvent(data.(*DiscussionCommentEvent))
+	case "WorkflowRunEvent":
+		err = c.handleWorkflowRunEvent(data.(*github.WorkflowRunEvent))
+	case "CheckSuiteEvent":
+		err = c.handleCheckSuiteEvent(data.(*github.CheckSuiteEvent))
+	case "CheckRunEvent":
+		err = c.handleCheckRunEvent(data.(*github.CheckRunEvent))
+	case "StatusEvent":
+		err = c.handleStatusEvent(data.(*github.StatusEvent))
+	case "DeploymentEvent":
+		err = c.handleDeploymentEvent(data.(*github.DeploymentEvent))
+	case "DeploymentStatusEvent":
+		err = c.handleDeploymentStatusEvent(data.(*github.DeploymentStatusEvent))
+	case "CreateEvent":
+		err = c.handleCreateEvent(data.(*github.CreateEvent))
+	case "DeleteEvent":
+		err = c.handleDeleteEvent(data.(*github.DeleteEvent))
+	case "DependabotAlertEvent":
+		err = c.handleDependabotAlertEvent(data.(*DependabotAlertEvent))
+	case "CodeScanningAlertEvent":
+		err = c.handleCodeScanningAlertEvent(data.(*github.CodeScanningAlertEvent))
+	case "SecretScanningAlertEvent":
+		err = c.handleSecretScanningAlertEvent(data.(*github.SecretScanningAlertEvent))
+	case "SecurityAdvisoryEvent":
+		err = c.handleSecurityAdvisoryEvent(data.(*SecurityAdvisoryEvent))
+	case "WatchEvent":
+		err = c.handleWatchEvent(data.(*github.WatchEvent))
+	case "ForkEvent":
+		err = c.handleForkEvent(data.(*github.ForkEvent))
+	case "MemberEvent":
+		err = c.handleMemberEvent(data.(*github.MemberEvent))
+	case "PublicEvent":
+		err = c.handlePublicEvent(data.(*github.PublicEvent))
+	case "SponsorshipEvent":
+		err = c.handleSponsorshipEvent(data.(*SponsorshipEvent))
+	case "MilestoneEvent":
+		err = c.handleMilestoneEvent(data.(*github.MilestoneEvent))
+	case "LabelEvent":
+		err = c.handleLabelEvent(data.(*github.LabelEvent))
+	case "CommitCommentEvent":
+		err = c.handleCommitCommentEvent(data.(*CommitCommentEvent))
+	case "GollumEvent":
+		err = c.handleGollumEvent(data.(*github.GollumEvent))
+	case "ProjectsV2ItemEvent":
+		err = c.handleProjectsV2ItemEvent(data.(*ProjectsV2ItemEvent))
+	case "ProjectCardEvent":
+		err = c.handleProjectCardEvent(data.(*ProjectCardEvent))
+	case "MergeGroupEvent":
+		err = c.handleMergeGroupEvent(data.(*MergeGroupEvent))
 	default:
 		return fmt.Errorf("unknown event type %q", *ev.Type)
 	}
@@ -130,6 +490,54 @@ func (c *Client) DoEvent(ev *github.Event) error {
 	return nil
 }
 
+// route returns a copy of c that sends the messages of the event data into the
+// parent channel of its repository, or of the rule matching it, and mentions
+// the rule's roles.
+func (c *Client) route(evType string, data any, rule *Rule) (*Client, error) {
+	routed := c.client
+
+	var ch discord.ChannelID
+	repo, hasRepo := eventRepoName(data)
+	if hasRepo {
+		ch = c.client.config.channelFor(repo)
+	}
+	if rule != nil && rule.channelFor(evType).IsValid() {
+		ch = rule.channelFor(evType)
+		routed = routed.withRuleChannel(ch)
+	}
+
+	switch {
+	case ch.IsValid():
+		routed = routed.forChannel(ch)
+	case hasRepo:
+		return nil, fmt.Errorf("no Discord channel for repository %q", repo)
+	}
+
+	if rule != nil && len(rule.Mentions) > 0 {
+		routed = routed.withMentions(rule.Mentions)
+	}
+
+	if routed == c.client {
+		return c, nil
+	}
+	return wrapClient(routed), nil
+}
+
+// eventRepoName returns the owner/repo name of the repository that the event
+// data belongs to.
+func eventRepoName(data any) (string, bool) {
+	switch data := data.(type) {
+	case interface{ GetRepo() *github.Repository }:
+		return data.GetRepo().GetFullName(), true
+	case interface {
+		GetRepo() *github.PushEventRepository
+	}:
+		return data.GetRepo().GetFullName(), true
+	default:
+		return "", false
+	}
+}
+
 // handleIssuesEvent handles an IssuesEvent
 //
 // https://docs.github.com/en/developers/webhooks-and-events/events/github-event-types#issuesevent
@@ -160,8 +568,14 @@ func (c *Client) handleIssuesEvent(ev *github.IssuesEvent) error {
 			err = c.Issues.EmbedUnassignedMsg(ev)
 		case "labeled":
 			err = c.Issues.EmbedLabeledMsg(ev)
+			if err == nil {
+				err = c.Issues.SyncForumTags(ev)
+			}
 		case "unlabeled":
 			err = c.Issues.EmbedUnlabeledMsg(ev)
+			if err == nil {
+				err = c.Issues.SyncForumTags(ev)
+			}
 		case "locked":
 			err = c.Issues.EmbedLockedMsg(ev)
 		case "unlocked":
@@ -183,10 +597,42 @@ func (c *Client) handleIssuesEvent(ev *github.IssuesEvent) error {
 	}
 }
 
+// deferredThread returns true if the thread of the pull request was deferred
+// by DeferDraftThreads, i.e. the option is set and the pull request has no
+// thread yet.
+func (c *Client) deferredThread(ev *github.PullRequestEvent) (bool, error) {
+	if !c.client.config.DeferDraftThreads {
+		return false, nil
+	}
+
+	_, err := c.client.discord.ExistingThread(threadKey(ev.GetRepo(), ev.GetNumber()))
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.Is(err, discordclient.ErrThreadNotFound):
+		return true, nil
+	default:
+		return false, fmt.Errorf("failed to find thread of pull request %d: %w", ev.GetNumber(), err)
+	}
+}
+
 // handlePullRequestEvent handles a PullRequestEvent
 //
 // https://docs.github.com/en/developers/webhooks-and-events/events/github-event-types#pullrequestevent
-func (c *Client) handlePREvent(ev *github.PullRequestEvent) error {
+func (c *Client) handlePREvent(data *PullRequestEvent) error {
+	ev := &data.PullRequestEvent
+
+	if ev.GetPullRequest().GetDraft() {
+		deferred, err := c.deferredThread(ev)
+		if err != nil {
+			return err
+		}
+		if deferred {
+			c.client.logger.Println("PRs: deferring thread of draft pull request", ev.GetNumber())
+			return nil
+		}
+	}
+
 	switch *ev.Action {
 	case "opened":
 		return c.PRs.OpenAndEmbedInitialMsg(ev)
@@ -201,9 +647,29 @@ func (c *Client) handlePREvent(ev *github.PullRequestEvent) error {
 	case "review_request_removed":
 		return c.PRs.EmbedReviewRequestRemovedMsg(ev)
 	case "ready_for_review":
-		return c.PRs.EmbedReadyForReviewMsg(ev)
+		// Deferred drafts get their thread once they are ready.
+		deferred, err := c.deferredThread(ev)
+		if err != nil {
+			return err
+		}
+		if deferred {
+			return c.PRs.OpenAndEmbedInitialMsg(ev)
+		}
+		if err := c.PRs.EmbedReadyForReviewMsg(ev); err != nil {
+			return err
+		}
+		return c.PRs.EditInitialMsg(ev)
+	case "converted_to_draft":
+		if err := c.PRs.EmbedConvertedToDraftMsg(ev); err != nil {
+			return err
+		}
+		return c.PRs.EditInitialMsg(ev)
+	case "auto_merge_enabled", "auto_merge_disabled":
+		return c.PRs.EmbedAutoMergeMsg(data)
+	case "enqueued", "dequeued":
+		return c.PRs.EmbedMergeQueueMsg(data)
 
-	case "closed", "reopened", "assigned", "unassigned", "labeled", "unlabeled", "locked", "unlocked", "milestoned", "demilestoned":
+	case "closed", "reopened", "assigned", "unassigned", "labeled", "unlabeled", "locked", "unlocked", "milestoned", "demilestoned", "synchronize":
 		var err error
 		switch *ev.Action {
 		case "closed":
@@ -219,8 +685,14 @@ func (c *Client) handlePREvent(ev *github.PullRequestEvent) error {
 			err = c.PRs.EmbedUnassignedMsg(ev)
 		case "labeled":
 			err = c.PRs.EmbedLabeledMsg(ev)
+			if err == nil {
+				err = c.PRs.SyncForumTags(ev)
+			}
 		case "unlabeled":
 			err = c.PRs.EmbedUnlabeledMsg(ev)
+			if err == nil {
+				err = c.PRs.SyncForumTags(ev)
+			}
 		case "locked":
 			err = c.PRs.EmbedLockedMsg(ev)
 		case "unlocked":
@@ -229,6 +701,8 @@ func (c *Client) handlePREvent(ev *github.PullRequestEvent) error {
 			err = c.PRs.EmbedMilestonedMsg(ev)
 		case "demilestoned":
 			err = c.PRs.EmbedDemilestonedMsg(ev)
+		case "synchronize":
+			err = c.PRs.EmbedSynchronizedMsg(ev)
 		}
 
 		if err != nil {
@@ -307,3 +781,292 @@ func (c *Client) handlePullRequestReviewThreadEvent(ev *github.PullRequestReview
 		return nil
 	}
 }
+
+// handlePushEvent handles a PushEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/events/github-event-types#pushevent
+func (c *Client) handlePushEvent(ev *github.PushEvent) error {
+	return c.Pushes.EmbedPushMsg(ev)
+}
+
+// handleReleaseEvent handles a ReleaseEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/events/github-event-types#releaseevent
+func (c *Client) handleReleaseEvent(ev *github.ReleaseEvent) error {
+	switch *ev.Action {
+	case "published", "prereleased":
+		return c.Releases.EmbedReleaseMsg(ev)
+	case "edited":
+		return c.Releases.EditReleaseMsg(ev)
+	case "deleted":
+		return c.Releases.EmbedReleaseDeletedMsg(ev)
+	default:
+		return nil
+	}
+}
+
+// handleDiscussionEvent handles a DiscussionEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#discussion
+func (c *Client) handleDiscussionEvent(ev *DiscussionEvent) error {
+	c = wrapClient(c.client.withThreadKind(store.DiscussionThread))
+
+	switch *ev.Action {
+	case "created":
+		return c.Discussions.OpenAndEmbedInitialMsg(ev)
+	case "deleted":
+		return c.Discussions.EmbedDeletedMsg(ev)
+	case "edited", "closed", "reopened", "locked", "unlocked", "pinned", "unpinned":
+		return c.Discussions.EditInitialMsg(ev)
+
+	case "answered", "unanswered", "category_changed", "labeled", "unlabeled":
+		var err error
+		switch *ev.Action {
+		case "answered":
+			err = c.Discussions.EmbedAnsweredMsg(ev)
+		case "unanswered":
+			err = c.Discussions.EmbedUnansweredMsg(ev)
+		}
+		if err == nil {
+			err = c.Discussions.SyncThread(ev)
+		}
+		if err != nil {
+			return err
+		}
+
+		return c.Discussions.EditInitialMsg(ev)
+
+	default:
+		return nil
+	}
+}
+
+// handleDiscussionCommentEvent handles a DiscussionCommentEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#discussion_comment
+func (c *Client) handleDiscussionCommentEvent(ev *DiscussionCommentEvent) error {
+	c = wrapClient(c.client.withThreadKind(store.DiscussionThread))
+
+	switch *ev.Action {
+	case "created":
+		return c.DiscussionComments.EmbedDiscussionCommentMsg(ev)
+	case "edited":
+		return c.DiscussionComments.EditDiscussionCommentMsg(ev)
+	case "deleted":
+		return c.DiscussionComments.EmbedDeletedMsg(ev)
+	default:
+		return nil
+	}
+}
+
+// handleWorkflowRunEvent handles a WorkflowRunEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#workflow_run
+func (c *Client) handleWorkflowRunEvent(ev *github.WorkflowRunEvent) error {
+	switch *ev.Action {
+	case "requested", "in_progress":
+		return c.Checks.EditWorkflowRunStatusMsg(ev)
+	case "completed":
+		if err := c.Checks.EditWorkflowRunStatusMsg(ev); err != nil {
+			return err
+		}
+		return c.Checks.EmbedWorkflowRunFailedMsg(ev)
+	default:
+		return nil
+	}
+}
+
+// handleCheckSuiteEvent handles a CheckSuiteEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/events/github-event-types#checksuiteevent
+func (c *Client) handleCheckSuiteEvent(ev *github.CheckSuiteEvent) error {
+	switch *ev.Action {
+	case "completed":
+		if err := c.Checks.EditCheckSuiteStatusMsg(ev); err != nil {
+			return err
+		}
+		return c.Checks.EmbedCheckSuiteFailedMsg(ev)
+	default:
+		return nil
+	}
+}
+
+// handleCheckRunEvent handles a CheckRunEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/events/github-event-types#checkrunevent
+func (c *Client) handleCheckRunEvent(ev *github.CheckRunEvent) error {
+	switch *ev.Action {
+	case "created", "completed", "rerequested":
+		return c.Checks.EditCheckRunStatusMsg(ev)
+	default:
+		return nil
+	}
+}
+
+// handleStatusEvent handles a StatusEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#status
+func (c *Client) handleStatusEvent(ev *github.StatusEvent) error {
+	return c.Statuses.EmbedStatusMsg(ev)
+}
+
+// handleDeploymentEvent handles a DeploymentEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#deployment
+func (c *Client) handleDeploymentEvent(ev *github.DeploymentEvent) error {
+	return c.Deployments.EmbedDeploymentMsg(ev)
+}
+
+// handleDeploymentStatusEvent handles a DeploymentStatusEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#deployment_status
+func (c *Client) handleDeploymentStatusEvent(ev *github.DeploymentStatusEvent) error {
+	return c.Deployments.EmbedDeploymentStatusMsg(ev)
+}
+
+// handleCreateEvent handles a CreateEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/events/github-event-types#createevent
+func (c *Client) handleCreateEvent(ev *github.CreateEvent) error {
+	return c.Refs.EmbedCreatedMsg(ev)
+}
+
+// handleDeleteEvent handles a DeleteEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/events/github-event-types#deleteevent
+func (c *Client) handleDeleteEvent(ev *github.DeleteEvent) error {
+	return c.Refs.EmbedDeletedMsg(ev)
+}
+
+// handleDependabotAlertEvent handles a DependabotAlertEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#dependabot_alert
+func (c *Client) handleDependabotAlertEvent(ev *DependabotAlertEvent) error {
+	return c.Security.EmbedDependabotAlertMsg(ev)
+}
+
+// handleCodeScanningAlertEvent handles a CodeScanningAlertEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#code_scanning_alert
+func (c *Client) handleCodeScanningAlertEvent(ev *github.CodeScanningAlertEvent) error {
+	switch ev.GetAction() {
+	case "appeared_in_branch":
+		// The alert already exists, it was only merged into another branch.
+		return nil
+	default:
+		return c.Security.EmbedCodeScanningAlertMsg(ev)
+	}
+}
+
+// handleSecretScanningAlertEvent handles a SecretScanningAlertEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#secret_scanning_alert
+func (c *Client) handleSecretScanningAlertEvent(ev *github.SecretScanningAlertEvent) error {
+	return c.Security.EmbedSecretScanningAlertMsg(ev)
+}
+
+// handleSecurityAdvisoryEvent handles a SecurityAdvisoryEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#security_advisory
+func (c *Client) handleSecurityAdvisoryEvent(ev *SecurityAdvisoryEvent) error {
+	return c.Security.EmbedSecurityAdvisoryMsg(ev)
+}
+
+// handleWatchEvent handles a WatchEvent, which is sent when a repository is
+// starred.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#watch
+func (c *Client) handleWatchEvent(ev *github.WatchEvent) error {
+	return c.Activity.EmbedStarMsg(ev)
+}
+
+// handleForkEvent handles a ForkEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#fork
+func (c *Client) handleForkEvent(ev *github.ForkEvent) error {
+	return c.Activity.EmbedForkMsg(ev)
+}
+
+// handleMemberEvent handles a MemberEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#member
+func (c *Client) handleMemberEvent(ev *github.MemberEvent) error {
+	return c.Activity.EmbedMemberMsg(ev)
+}
+
+// handlePublicEvent handles a PublicEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#public
+func (c *Client) handlePublicEvent(ev *github.PublicEvent) error {
+	return c.Activity.EmbedPublicMsg(ev)
+}
+
+// handleSponsorshipEvent handles a SponsorshipEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#sponsorship
+func (c *Client) handleSponsorshipEvent(ev *SponsorshipEvent) error {
+	switch ev.GetAction() {
+	case "created", "cancelled", "tier_changed":
+		return c.Activity.EmbedSponsorshipMsg(ev)
+	default:
+		return nil
+	}
+}
+
+// handleMilestoneEvent handles a MilestoneEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#milestone
+func (c *Client) handleMilestoneEvent(ev *github.MilestoneEvent) error {
+	if err := c.Milestones.EmbedMilestoneMsg(ev); err != nil {
+		return err
+	}
+
+	if ev.GetAction() == "edited" {
+		return c.Milestones.EditRenamedMsgs(ev)
+	}
+
+	return nil
+}
+
+// handleLabelEvent handles a LabelEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#label
+func (c *Client) handleLabelEvent(ev *github.LabelEvent) error {
+	return c.Labels.EmbedLabelMsg(ev)
+}
+
+// handleCommitCommentEvent handles a CommitCommentEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#commit_comment
+func (c *Client) handleCommitCommentEvent(ev *CommitCommentEvent) error {
+	return c.CommitComments.EmbedCommitCommentMsg(ev)
+}
+
+// handleGollumEvent handles a GollumEvent, which is sent when wiki pages are
+// created or edited.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#gollum
+func (c *Client) handleGollumEvent(ev *github.GollumEvent) error {
+	return c.Wiki.EmbedWikiMsg(ev)
+}
+
+// handleProjectsV2ItemEvent handles a ProjectsV2ItemEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#projects_v2_item
+func (c *Client) handleProjectsV2ItemEvent(ev *ProjectsV2ItemEvent) error {
+	return c.Projects.EmbedItemMsg(ev)
+}
+
+// handleProjectCardEvent handles a ProjectCardEvent of a classic project
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#project_card
+func (c *Client) handleProjectCardEvent(ev *ProjectCardEvent) error {
+	return c.Projects.EmbedCardMsg(ev)
+}
+
+// handleMergeGroupEvent handles a MergeGroupEvent
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#merge_group
+func (c *Client) handleMergeGroupEvent(ev *MergeGroupEvent) error {
+	return c.PRs.EmbedMergeGroupMsg(ev)
+}
@@ -0,0 +1,128 @@
+package gitcord
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/google/go-github/v47/github"
+	"golang.org/x/oauth2"
+)
+
+func TestEventRepoName(t *testing.T) {
+	repo := &github.Repository{FullName: github.String("o/r")}
+
+	type test struct {
+		name string
+		data any
+		repo string
+		ok   bool
+	}
+
+	tests := []test{
+		{name: "go-github event", data: &github.IssuesEvent{Repo: repo}, repo: "o/r", ok: true},
+		{name: "push", data: &github.PushEvent{Repo: &github.PushEventRepository{FullName: github.String("o/r")}}, repo: "o/r", ok: true},
+		{name: "local event", data: &DiscussionCommentEvent{Repo: repo}, repo: "o/r", ok: true},
+		{name: "extended event", data: &PullRequestEvent{PullRequestEvent: github.PullRequestEvent{Repo: repo}}, repo: "o/r", ok: true},
+		{name: "extended project card", data: &ProjectCardEvent{ProjectCardEvent: github.ProjectCardEvent{Repo: repo}}, repo: "o/r", ok: true},
+		{name: "no repository", data: &ProjectsV2ItemEvent{}},
+	}
+
+	for _, test := range tests {
+		repo, ok := eventRepoName(test.data)
+		if repo != test.repo || ok != test.ok {
+			t.Errorf("%s: eventRepoName = %q, %v, want %q, %v", test.name, repo, ok, test.repo, test.ok)
+		}
+	}
+}
+
+// rewriteTransport sends every request to the test server at host instead.
+type rewriteTransport struct{ host string }
+
+func (t rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = "http"
+	r.URL.Host = t.host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestHandlePRSynchronize(t *testing.T) {
+	var sent []string
+	var edited bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/compare/", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status": "ahead", "total_commits": 1, "commits": [{"sha": "b", "commit": {"message": "Fix typo"}}]}`)
+	})
+	mux.HandleFunc(api.Path+"/channels/5", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": "5", "type": 11, "parent_id": "1"}`)
+	})
+	mux.HandleFunc(api.Path+"/channels/5/messages", func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Embeds []struct {
+				Title string `json:"title"`
+			} `json:"embeds"`
+		}
+		json.NewDecoder(r.Body).Decode(&msg)
+		for _, embed := range msg.Embeds {
+			sent = append(sent, embed.Title)
+		}
+		io.WriteString(w, `{"id": "7", "channel_id": "5"}`)
+	})
+	mux.HandleFunc(api.Path+"/channels/5/messages/6", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			edited = true
+		}
+		io.WriteString(w, `{"id": "6", "channel_id": "5", "embeds": [{"title": "Pull request opened: #12"}]}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := store.NewMemory()
+	s.SetThread(store.Key{Repo: "o/r", Number: 12}, store.Thread{ChannelID: 5, MessageID: 6})
+
+	c := NewClient(Config{
+		GitHubOAuth:      oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		DiscordToken:     "Bot token",
+		DiscordChannelID: 1,
+		Store:            s,
+		Logger:           log.New(io.Discard, "", 0),
+	})
+	c.client.github.BaseURL, _ = url.Parse(srv.URL + "/")
+	c.client.discord.Client.Client.Client = httpdriver.WrapClient(http.Client{
+		Transport: rewriteTransport{host: strings.TrimPrefix(srv.URL, "http://")},
+	})
+
+	var ev PullRequestEvent
+	err := json.Unmarshal([]byte(`{
+		"action": "synchronize",
+		"number": 12,
+		"before": "a",
+		"after": "b",
+		"pull_request": {"number": 12, "html_url": "https://github.com/o/r/pull/12"},
+		"repository": {"full_name": "o/r", "name": "r", "owner": {"login": "o"}}
+	}`), &ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.handlePREvent(&ev); err != nil {
+		t.Fatal("failed to handle synchronize:", err)
+	}
+
+	if len(sent) != 1 || sent[0] != "Pull request #12: 1 new commit" {
+		t.Errorf("unexpected messages %q", sent)
+	}
+	if !edited {
+		t.Error("initial message was not refreshed")
+	}
+}
@@ -0,0 +1,166 @@
+package gitcord
+
+import (
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// ActivityClient posts stars, forks, collaborators, repositories made public
+// and sponsorships into the activity channel.
+type ActivityClient client
+
+func (c *ActivityClient) logln(v ...any) {
+	prefixed := []any{"Activity:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+// EmbedStarMsg posts a new stargazer. Stars are batched if
+// Channels.ActivityBatch is set.
+func (c *ActivityClient) EmbedStarMsg(ev *github.WatchEvent) error {
+	return c.sendBatched("star", ev.GetRepo(), ev, func(evs []any) discord.Embed {
+		stars := make([]*github.WatchEvent, len(evs))
+		for i, ev := range evs {
+			stars[i] = ev.(*github.WatchEvent)
+		}
+		return c.config.makeStarEmbed(stars)
+	})
+}
+
+// EmbedForkMsg posts a new fork. Forks are batched if Channels.ActivityBatch
+// is set.
+func (c *ActivityClient) EmbedForkMsg(ev *github.ForkEvent) error {
+	return c.sendBatched("fork", ev.GetRepo(), ev, func(evs []any) discord.Embed {
+		forks := make([]*github.ForkEvent, len(evs))
+		for i, ev := range evs {
+			forks[i] = ev.(*github.ForkEvent)
+		}
+		return c.config.makeForkEmbed(forks)
+	})
+}
+
+func (c *ActivityClient) EmbedMemberMsg(ev *github.MemberEvent) error {
+	return c.sendEmbed(c.config.makeMemberEmbed(ev))
+}
+
+func (c *ActivityClient) EmbedPublicMsg(ev *github.PublicEvent) error {
+	return c.sendEmbed(c.config.makePublicEmbed(ev))
+}
+
+func (c *ActivityClient) EmbedSponsorshipMsg(ev *SponsorshipEvent) error {
+	return c.sendEmbed(c.config.makeSponsorshipEmbed(ev))
+}
+
+func (c *ActivityClient) sendEmbed(embed discord.Embed) error {
+	ch := (*client)(c).feedChannel(c.config.Channels.Activity)
+	if !ch.IsValid() {
+		c.logln("no channel for", embed.Title)
+		return nil
+	}
+
+	_, err := c.discord.SendEmbeds(ch, embed)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+// sendBatched sends the embed made of ev, or, if a message for events of the
+// same kind and repository was sent within the last Channels.ActivityBatch,
+// edits that message to include ev.
+func (c *ActivityClient) sendBatched(kind string, repo *github.Repository, ev any, makeEmbed func(evs []any) discord.Embed) error {
+	window := c.config.Channels.ActivityBatch
+	if window <= 0 {
+		return c.sendEmbed(makeEmbed([]any{ev}))
+	}
+
+	ch := (*client)(c).feedChannel(c.config.Channels.Activity)
+	if !ch.IsValid() {
+		c.logln("no channel for", kind, "of", repo.GetFullName())
+		return nil
+	}
+
+	b := c.activity.batch(activityKey{ch, repo.GetFullName(), kind}, time.Now(), window)
+
+	// Only the batch is locked while talking to Discord, so that a slow
+	// request doesn't hold up the activity of other repositories.
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events = append(b.events, ev)
+
+	if b.msgID.IsValid() {
+		_, err := c.discord.EditEmbeds(ch, b.msgID, makeEmbed(b.events))
+		if err == nil {
+			return nil
+		}
+		c.logln("failed to edit batched message, sending a new one:", err)
+	}
+
+	// A new message takes over the events of the batch so far.
+	msg, err := c.discord.SendEmbeds(ch, makeEmbed(b.events))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	b.msgID = msg.ID
+	return nil
+}
+
+// activityBatches are the batches of activity currently being collected. They
+// are shared by all copies of a client.
+type activityBatches struct {
+	mu      sync.Mutex // guards batches
+	batches map[activityKey]*activityBatch
+}
+
+type activityKey struct {
+	ch   discord.ChannelID
+	repo string
+	kind string
+}
+
+type activityBatch struct {
+	started time.Time
+
+	mu sync.Mutex // guards the fields below
+	// msgID is the message showing the events, if it was sent.
+	msgID  discord.MessageID
+	events []any
+}
+
+func newActivityBatches() *activityBatches {
+	return &activityBatches{batches: make(map[activityKey]*activityBatch)}
+}
+
+// batch returns the batch of k, starting a new one if there is none or it was
+// started longer than window ago.
+func (b *activityBatches) batch(k activityKey, now time.Time, window time.Duration) *activityBatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire(now, window)
+
+	batch, ok := b.batches[k]
+	if !ok {
+		batch = &activityBatch{started: now}
+		b.batches[k] = batch
+	}
+
+	return batch
+}
+
+// expire removes the batches started longer than window ago. b.mu must be
+// held.
+func (b *activityBatches) expire(now time.Time, window time.Duration) {
+	for k, batch := range b.batches {
+		if now.Sub(batch.started) >= window {
+			delete(b.batches, k)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package gitcord
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivityBatches(t *testing.T) {
+	batches := newActivityBatches()
+	k := activityKey{ch: 1, repo: "o/r", kind: "star"}
+	start := time.Now()
+
+	first := batches.batch(k, start, time.Minute)
+	first.events = append(first.events, "a")
+
+	if b := batches.batch(k, start.Add(30*time.Second), time.Minute); b != first {
+		t.Error("batch within the window was not reused")
+	}
+	if b := batches.batch(activityKey{ch: 1, repo: "o/r", kind: "fork"}, start, time.Minute); b == first {
+		t.Error("batch of another kind was reused")
+	}
+	if b := batches.batch(k, start.Add(time.Minute), time.Minute); b == first || len(b.events) != 0 {
+		t.Error("batch after the window was reused")
+	}
+}
@@ -0,0 +1,179 @@
+package gitcord
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// ChecksClient keeps a CI status message up to date in the threads of pull
+// requests, and posts the failures of workflow runs and check suites into
+// them.
+type ChecksClient client
+
+func (c *ChecksClient) logln(v ...any) {
+	prefixed := []any{"Checks:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+// maxCheckRuns is the maximum number of check runs fetched for the CI status
+// message.
+const maxCheckRuns = 100
+
+// EditWorkflowRunStatusMsg updates the CI status messages of the pull requests
+// of a workflow run.
+func (c *ChecksClient) EditWorkflowRunStatusMsg(ev *github.WorkflowRunEvent) error {
+	run := ev.GetWorkflowRun()
+	return c.editStatusMsgs(ev.GetRepo(), run.GetHeadSHA(), run.PullRequests)
+}
+
+// EditCheckSuiteStatusMsg updates the CI status messages of the pull requests
+// of a check suite.
+func (c *ChecksClient) EditCheckSuiteStatusMsg(ev *github.CheckSuiteEvent) error {
+	suite := ev.GetCheckSuite()
+	return c.editStatusMsgs(ev.GetRepo(), suite.GetHeadSHA(), suite.PullRequests)
+}
+
+// EditCheckRunStatusMsg updates the CI status messages of the pull requests of
+// a check run.
+func (c *ChecksClient) EditCheckRunStatusMsg(ev *github.CheckRunEvent) error {
+	run := ev.GetCheckRun()
+	return c.editStatusMsgs(ev.GetRepo(), run.GetHeadSHA(), run.PullRequests)
+}
+
+// EmbedWorkflowRunFailedMsg posts the failing jobs of a failed workflow run
+// into the threads of its pull requests.
+func (c *ChecksClient) EmbedWorkflowRunFailedMsg(ev *github.WorkflowRunEvent) error {
+	run := ev.GetWorkflowRun()
+	if !checkFailed(run.GetConclusion()) {
+		return nil
+	}
+
+	owner, name, err := splitRepo(ev.GetRepo())
+	if err != nil {
+		return err
+	}
+
+	jobs, _, err := c.github.Actions.ListWorkflowJobs(c.github.Context(), owner, name, run.GetID(), &github.ListWorkflowJobsOptions{
+		ListOptions: github.ListOptions{PerPage: maxCheckRuns},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list jobs")
+	}
+
+	var failed []checkLink
+	for _, job := range jobs.Jobs {
+		if checkFailed(job.GetConclusion()) {
+			failed = append(failed, checkLink{job.GetName(), job.GetHTMLURL()})
+		}
+	}
+
+	embed := c.config.makeCIFailedEmbed(run.GetName(), run.GetHTMLURL(), run.GetHeadSHA(), failed)
+	return c.embedFailedMsgs(ev.GetRepo(), run.GetHeadSHA(), run.PullRequests, embed)
+}
+
+// EmbedCheckSuiteFailedMsg posts the failing check runs of a failed check
+// suite into the threads of its pull requests. Check suites of GitHub Actions
+// are left to EmbedWorkflowRunFailedMsg.
+func (c *ChecksClient) EmbedCheckSuiteFailedMsg(ev *github.CheckSuiteEvent) error {
+	suite := ev.GetCheckSuite()
+	if !checkFailed(suite.GetConclusion()) || suite.GetApp().GetSlug() == "github-actions" {
+		return nil
+	}
+
+	owner, name, err := splitRepo(ev.GetRepo())
+	if err != nil {
+		return err
+	}
+
+	runs, _, err := c.github.Checks.ListCheckRunsCheckSuite(c.github.Context(), owner, name, suite.GetID(), &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: maxCheckRuns},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list check runs")
+	}
+
+	var failed []checkLink
+	for _, run := range runs.CheckRuns {
+		if checkFailed(run.GetConclusion()) {
+			failed = append(failed, checkLink{run.GetName(), run.GetHTMLURL()})
+		}
+	}
+
+	embed := c.config.makeCIFailedEmbed(suite.GetApp().GetName(), ev.GetRepo().GetHTMLURL()+"/commit/"+suite.GetHeadSHA()+"/checks", suite.GetHeadSHA(), failed)
+	return c.embedFailedMsgs(ev.GetRepo(), suite.GetHeadSHA(), suite.PullRequests, embed)
+}
+
+// editStatusMsgs sends or edits the CI status message in the thread of each
+// pull request whose head is sha.
+func (c *ChecksClient) editStatusMsgs(repo *github.Repository, sha string, prs []*github.PullRequest) error {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	threads := (*client)(c).headThreads(repo, sha, prs)
+	if len(threads) == 0 {
+		return nil
+	}
+
+	runs, _, err := c.github.Checks.ListCheckRunsForRef(c.github.Context(), owner, name, sha, &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: maxCheckRuns},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list check runs")
+	}
+
+	for _, t := range threads {
+		embed := c.config.makeCIStatusEmbed(t.pr, sha, runs.CheckRuns)
+		if err := c.editStatusMsg(t.id, embed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// editStatusMsg edits the CI status message of thread ch, or sends it if
+// there is none yet.
+func (c *ChecksClient) editStatusMsg(ch discord.ChannelID, embed discord.Embed) error {
+	k := store.MessageKey{Kind: store.CIStatusMsg, ID: int64(ch)}
+
+	if m, err := c.store.Message(k); err == nil {
+		_, err = c.discord.EditEmbeds(m.ChannelID, m.MessageID, embed)
+		if err == nil {
+			return nil
+		}
+		c.logln("failed to edit CI status message, sending a new one:", err)
+	}
+
+	_, err := c.discord.SendEmbedsFor(k, ch, embed)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+func (c *ChecksClient) embedFailedMsgs(repo *github.Repository, sha string, prs []*github.PullRequest, embed discord.Embed) error {
+	for _, t := range (*client)(c).headThreads(repo, sha, prs) {
+		_, err := c.discord.SendEmbeds(t.id, embed)
+		if err != nil {
+			return errors.Wrap(err, "failed to send message")
+		}
+	}
+
+	return nil
+}
+
+// checkFailed returns whether a check conclusion is a failure.
+func checkFailed(conclusion string) bool {
+	switch conclusion {
+	case "failure", "timed_out", "startup_failure":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,149 @@
+package gitcord
+
+import (
+	"fmt"
+
+	"github.com/ethanthatonekid/gitcord/gitcord/internal/githubclient"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// CommandsClient acts on issues and pull requests on behalf of Discord slash
+// commands. The resulting GitHub events are mirrored back into Discord like
+// any other.
+type CommandsClient client
+
+// repoClient returns the GitHub client authenticated for the repository of k
+// along with the repository's owner and name.
+func (c *CommandsClient) repoClient(k store.Key) (gh *githubclient.Client, owner, repo string, err error) {
+	owner, repo, ok := k.SplitRepo()
+	if !ok {
+		return nil, "", "", fmt.Errorf("invalid repository %q", k.Repo)
+	}
+
+	gh, err = c.github.ForRepo(owner, repo)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return gh, owner, repo, nil
+}
+
+// Close closes the issue or pull request k. The reason is either "completed",
+// "not_planned" or empty.
+func (c *CommandsClient) Close(k store.Key, reason string) error {
+	gh, owner, repo, err := c.repoClient(k)
+	if err != nil {
+		return err
+	}
+
+	req := &github.IssueRequest{State: github.String("closed")}
+	if reason != "" {
+		req.StateReason = &reason
+	}
+
+	_, _, err = gh.Issues.Edit(gh.Context(), owner, repo, k.Number, req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to close %s", k)
+	}
+
+	return nil
+}
+
+// Reopen reopens the issue or pull request k.
+func (c *CommandsClient) Reopen(k store.Key) error {
+	gh, owner, repo, err := c.repoClient(k)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = gh.Issues.Edit(gh.Context(), owner, repo, k.Number, &github.IssueRequest{
+		State: github.String("open"),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to reopen %s", k)
+	}
+
+	return nil
+}
+
+// AddLabel adds the label to the issue or pull request k.
+func (c *CommandsClient) AddLabel(k store.Key, label string) error {
+	gh, owner, repo, err := c.repoClient(k)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = gh.Issues.AddLabelsToIssue(gh.Context(), owner, repo, k.Number, []string{label})
+	if err != nil {
+		return errors.Wrapf(err, "failed to add label %q to %s", label, k)
+	}
+
+	return nil
+}
+
+// RemoveLabel removes the label from the issue or pull request k.
+func (c *CommandsClient) RemoveLabel(k store.Key, label string) error {
+	gh, owner, repo, err := c.repoClient(k)
+	if err != nil {
+		return err
+	}
+
+	_, err = gh.Issues.RemoveLabelForIssue(gh.Context(), owner, repo, k.Number, label)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove label %q from %s", label, k)
+	}
+
+	return nil
+}
+
+// Assign assigns the GitHub user to the issue or pull request k.
+func (c *CommandsClient) Assign(k store.Key, login string) error {
+	gh, owner, repo, err := c.repoClient(k)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = gh.Issues.AddAssignees(gh.Context(), owner, repo, k.Number, []string{login})
+	if err != nil {
+		return errors.Wrapf(err, "failed to assign %s to %s", login, k)
+	}
+
+	return nil
+}
+
+// RequestReview requests a review from the GitHub user on the pull request k.
+func (c *CommandsClient) RequestReview(k store.Key, login string) error {
+	gh, owner, repo, err := c.repoClient(k)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = gh.PullRequests.RequestReviewers(gh.Context(), owner, repo, k.Number, github.ReviewersRequest{
+		Reviewers: []string{login},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to request review from %s on %s", login, k)
+	}
+
+	return nil
+}
+
+// Lock locks the conversation of the issue or pull request k. The reason is
+// one of "off-topic", "too heated", "resolved", "spam" or empty.
+func (c *CommandsClient) Lock(k store.Key, reason string) error {
+	gh, owner, repo, err := c.repoClient(k)
+	if err != nil {
+		return err
+	}
+
+	_, err = gh.Issues.Lock(gh.Context(), owner, repo, k.Number, &github.LockIssueOptions{
+		LockReason: reason,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to lock %s", k)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package gitcord
 import (
 	"fmt"
 
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
 	"github.com/google/go-github/v47/github"
 	"github.com/pkg/errors"
 )
@@ -12,12 +13,18 @@ type IssueCommentClient client
 func (c *IssueCommentClient) EmbedIssueCommentMsg(ev *github.IssueCommentEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	if (*client)(c).isReplyComment(ev.GetComment()) {
+		return nil
+	}
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
 
-	_, err = c.discord.SendEmbeds(t.ID, c.config.makeIssueCommentEmbed(ev))
+	k := store.MessageKey{Kind: store.IssueCommentMsg, ID: ev.GetComment().GetID()}
+
+	_, err = c.discord.SendEmbedsFor(k, t.ID, c.config.makeIssueCommentEmbed(ev))
 	if err != nil {
 		return errors.Wrap(err, "failed to send message")
 	}
@@ -28,12 +35,16 @@ func (c *IssueCommentClient) EmbedIssueCommentMsg(ev *github.IssueCommentEvent)
 func (c *IssueCommentClient) EditIssueCommentMsg(ev *github.IssueCommentEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	if (*client)(c).isReplyComment(ev.GetComment()) {
+		return nil
+	}
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
 
-	msg := c.discord.FindMsgByComment(t, ev.GetComment().GetID())
+	msg := c.discord.FindMsg(store.MessageKey{Kind: store.IssueCommentMsg, ID: ev.GetComment().GetID()}, t)
 	if msg == nil {
 		return fmt.Errorf("failed to find message")
 	}
@@ -49,7 +60,7 @@ func (c *IssueCommentClient) EditIssueCommentMsg(ev *github.IssueCommentEvent) e
 func (c *IssueCommentClient) EmbedDeletedMsg(ev *github.IssueCommentEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
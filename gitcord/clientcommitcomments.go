@@ -0,0 +1,51 @@
+package gitcord
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/pkg/errors"
+)
+
+// CommitCommentsClient posts comments on commits into the threads of the open
+// pull requests containing the commit, or else into the commits channel.
+type CommitCommentsClient client
+
+func (c *CommitCommentsClient) logln(v ...any) {
+	prefixed := []any{"Commit comments:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+func (c *CommitCommentsClient) EmbedCommitCommentMsg(ev *CommitCommentEvent) error {
+	sha := ev.GetComment().GetCommitID()
+
+	prs, err := (*client)(c).commitPRs(ev.GetRepo(), sha)
+	if err != nil {
+		c.logln("failed to find pull requests of", shortSHA(sha)+":", err)
+	}
+
+	var chs []discord.ChannelID
+	for _, pr := range prs {
+		t, err := c.discord.ExistingThread(threadKey(ev.GetRepo(), pr.GetNumber()))
+		if err == nil {
+			chs = append(chs, t.ID)
+		}
+	}
+
+	if len(chs) == 0 {
+		ch := (*client)(c).feedChannel(c.config.Channels.Commits)
+		if !ch.IsValid() {
+			c.logln("no channel for comment on", shortSHA(sha))
+			return nil
+		}
+		chs = append(chs, ch)
+	}
+
+	embed := c.config.makeCommitCommentEmbed(ev)
+	for _, ch := range chs {
+		if _, err := c.discord.SendEmbeds(ch, embed); err != nil {
+			return errors.Wrap(err, "failed to send message")
+		}
+	}
+
+	return nil
+}
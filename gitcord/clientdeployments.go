@@ -0,0 +1,59 @@
+package gitcord
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// DeploymentsClient posts deployments and their statuses into the thread of
+// the pull request whose head was deployed, or else into the deployments
+// channel.
+type DeploymentsClient client
+
+func (c *DeploymentsClient) logln(v ...any) {
+	prefixed := []any{"Deployments:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+func (c *DeploymentsClient) EmbedDeploymentMsg(ev *github.DeploymentEvent) error {
+	return c.sendEmbeds(ev.GetRepo(), ev.GetDeployment().GetSHA(), c.config.makeDeploymentEmbed(ev))
+}
+
+func (c *DeploymentsClient) EmbedDeploymentStatusMsg(ev *github.DeploymentStatusEvent) error {
+	return c.sendEmbeds(ev.GetRepo(), ev.GetDeployment().GetSHA(), c.config.makeDeploymentStatusEmbed(ev))
+}
+
+func (c *DeploymentsClient) sendEmbeds(repo *github.Repository, sha string, embeds ...discord.Embed) error {
+	chs := (*client)(c).shaChannels(repo, sha, c.config.Channels.Deployments)
+	if len(chs) == 0 {
+		c.logln("no channel for deployment of", shortSHA(sha))
+		return nil
+	}
+
+	for _, ch := range chs {
+		if _, err := c.discord.SendEmbeds(ch, embeds...); err != nil {
+			return errors.Wrap(err, "failed to send message")
+		}
+	}
+
+	return nil
+}
+
+// shaChannels returns the threads of the open pull requests whose head is sha,
+// or else the feed channel feed, if set.
+func (c *client) shaChannels(repo *github.Repository, sha string, feed discord.ChannelID) []discord.ChannelID {
+	var chs []discord.ChannelID
+	for _, t := range c.headThreads(repo, sha, nil) {
+		chs = append(chs, t.id)
+	}
+
+	if len(chs) == 0 {
+		if ch := c.feedChannel(feed); ch.IsValid() {
+			chs = append(chs, ch)
+		}
+	}
+
+	return chs
+}
@@ -0,0 +1,80 @@
+package gitcord
+
+import (
+	"fmt"
+
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/pkg/errors"
+)
+
+type DiscussionCommentsClient client
+
+// EmbedDiscussionCommentMsg forwards a comment into the thread of its
+// discussion. Replies to comments are sent as Discord replies to the message
+// of the comment they reply to, if it is known.
+func (c *DiscussionCommentsClient) EmbedDiscussionCommentMsg(ev *DiscussionCommentEvent) error {
+	d, comment := ev.GetDiscussion(), ev.GetComment()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), d.GetNumber()))
+	if err != nil {
+		return fmt.Errorf("discussion %d does not have a thread", d.GetNumber())
+	}
+
+	k := store.MessageKey{Kind: store.DiscussionCommentMsg, ID: comment.GetID()}
+	embed := c.config.makeDiscussionCommentEmbed(ev)
+
+	if parentID := comment.GetParentID(); parentID != 0 {
+		parent, err := c.store.Message(store.MessageKey{Kind: store.DiscussionCommentMsg, ID: parentID})
+		if err == nil && parent.ChannelID == t.ID {
+			_, err = c.discord.ReplyEmbedsFor(k, t.ID, parent.MessageID, embed)
+			if err != nil {
+				return errors.Wrap(err, "failed to send message")
+			}
+			return nil
+		}
+	}
+
+	_, err = c.discord.SendEmbedsFor(k, t.ID, embed)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+func (c *DiscussionCommentsClient) EditDiscussionCommentMsg(ev *DiscussionCommentEvent) error {
+	d := ev.GetDiscussion()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), d.GetNumber()))
+	if err != nil {
+		return fmt.Errorf("discussion %d does not have a thread", d.GetNumber())
+	}
+
+	msg := c.discord.FindMsg(store.MessageKey{Kind: store.DiscussionCommentMsg, ID: ev.GetComment().GetID()}, t)
+	if msg == nil {
+		return fmt.Errorf("failed to find message")
+	}
+
+	_, err = c.discord.EditEmbeds(t.ID, msg.ID, c.config.makeDiscussionCommentEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to edit message")
+	}
+
+	return nil
+}
+
+func (c *DiscussionCommentsClient) EmbedDeletedMsg(ev *DiscussionCommentEvent) error {
+	d := ev.GetDiscussion()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), d.GetNumber()))
+	if err != nil {
+		return fmt.Errorf("discussion %d does not have a thread", d.GetNumber())
+	}
+
+	_, err = c.discord.SendEmbeds(t.ID, c.config.makeDiscussionCommentDeletedEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package gitcord
+
+import (
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/ethanthatonekid/gitcord/gitcord/internal/discordclient"
+	"github.com/pkg/errors"
+)
+
+// DiscussionsClient mirrors discussions into threads, much like IssuesClient
+// does for issues. Discussions share their numbers with issues and pull
+// requests, so their threads are keyed the same way.
+type DiscussionsClient client
+
+func (c *DiscussionsClient) logln(v ...any) {
+	prefixed := []any{"Discussions:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+func (c *DiscussionsClient) OpenAndEmbedInitialMsg(ev *DiscussionEvent) error {
+	d := ev.GetDiscussion()
+	k := threadKey(ev.GetRepo(), d.GetNumber())
+
+	t, err := c.discord.ExistingThread(k)
+	if err == nil {
+		if !c.config.ForceOpen {
+			c.logln("discussion", d.GetNumber(), "already has a thread")
+			return fmt.Errorf("discussion %d already has a thread %d", d.GetNumber(), t.ID)
+		}
+		c.logln(fmt.Sprintf("ignoring existing thread %d", t.ID))
+	}
+
+	t, msg, err := c.discord.OpenThread(discordclient.OpenThreadData{
+		Name:       (*client)(c).threadName(k, discussionThreadTitle(d)),
+		Embed:      c.config.makeDiscussionEmbed(d),
+		Tags:       discussionTags(d),
+		CreateTags: c.config.CreateForumTags,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.discord.RecordThread(k, t.ID, msg.ID); err != nil {
+		return errors.Wrap(err, "failed to record thread")
+	}
+
+	return nil
+}
+
+func (c *DiscussionsClient) EditInitialMsg(ev *DiscussionEvent) error {
+	d := ev.GetDiscussion()
+	k := threadKey(ev.GetRepo(), d.GetNumber())
+
+	t, err := c.discord.FindThread(k)
+	if err != nil {
+		return fmt.Errorf("discussion %d does not have a thread", d.GetNumber())
+	}
+
+	msg := c.discord.FindInitialMsg(k, t, c.discord.FindMsgByDiscussion)
+	if msg == nil {
+		return fmt.Errorf("discussion %d does not have an initial message", d.GetNumber())
+	}
+
+	_, err = c.discord.EditEmbeds(t.ID, msg.ID, c.config.makeDiscussionEmbed(d))
+	if err != nil {
+		return errors.Wrap(err, "failed to edit message")
+	}
+
+	return nil
+}
+
+func (c *DiscussionsClient) EmbedAnsweredMsg(ev *DiscussionEvent) error {
+	d := ev.GetDiscussion()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), d.GetNumber()))
+	if err != nil {
+		return fmt.Errorf("discussion %d does not have a thread", d.GetNumber())
+	}
+
+	_, err = c.discord.SendEmbeds(t.ID, c.config.makeDiscussionAnsweredEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+func (c *DiscussionsClient) EmbedUnansweredMsg(ev *DiscussionEvent) error {
+	d := ev.GetDiscussion()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), d.GetNumber()))
+	if err != nil {
+		return fmt.Errorf("discussion %d does not have a thread", d.GetNumber())
+	}
+
+	_, err = c.discord.SendEmbeds(t.ID, c.config.makeDiscussionUnansweredEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+func (c *DiscussionsClient) EmbedDeletedMsg(ev *DiscussionEvent) error {
+	d := ev.GetDiscussion()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), d.GetNumber()))
+	if err != nil {
+		return fmt.Errorf("discussion %d does not have a thread", d.GetNumber())
+	}
+
+	_, err = c.discord.SendEmbeds(t.ID, c.config.makeDiscussionDeletedEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+// SyncThread renames the thread of the discussion after its title, marking
+// answered discussions, and applies its category and labels as tags if the
+// thread is a forum post.
+func (c *DiscussionsClient) SyncThread(ev *DiscussionEvent) error {
+	d := ev.GetDiscussion()
+	k := threadKey(ev.GetRepo(), d.GetNumber())
+
+	t, err := c.discord.FindThread(k)
+	if err != nil {
+		return fmt.Errorf("discussion %d does not have a thread", d.GetNumber())
+	}
+
+	name := (*client)(c).threadName(k, discussionThreadTitle(d))
+	if t.Name != name {
+		if err := c.discord.ModifyChannel(t.ID, api.ModifyChannelData{Name: name}); err != nil {
+			return errors.Wrap(err, "failed to rename thread")
+		}
+	}
+
+	err = c.discord.SetThreadTags(t, discussionTags(d), c.config.CreateForumTags)
+	if err != nil {
+		return errors.Wrap(err, "failed to set forum tags")
+	}
+
+	return nil
+}
+
+// discussionThreadTitle returns the title of the discussion, marked if it is
+// answered.
+func discussionThreadTitle(d *Discussion) string {
+	if d.GetAnswerHTMLURL() != "" {
+		return "✅ " + d.GetTitle()
+	}
+	return d.GetTitle()
+}
+
+// discussionTags returns the names of the forum tags of the discussion: its
+// category, its labels and whether it is answered.
+func discussionTags(d *Discussion) []string {
+	tags := []string{d.GetCategory().GetName()}
+	if d.GetAnswerHTMLURL() != "" {
+		tags = append(tags, "Answered")
+	}
+	return append(tags, labelNames(d.Labels)...)
+}
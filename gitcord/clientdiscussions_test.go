@@ -0,0 +1,32 @@
+package gitcord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+)
+
+func TestDiscussionThread(t *testing.T) {
+	open := &Discussion{
+		Title:    github.String("Help"),
+		Category: &github.DiscussionCategory{Name: github.String("Q&A")},
+		Labels:   []*github.Label{{Name: github.String("question")}},
+	}
+	answered := *open
+	answered.AnswerHTMLURL = github.String("https://github.com/o/r/discussions/7#discussioncomment-42")
+
+	if got := discussionThreadTitle(open); got != "Help" {
+		t.Errorf("unexpected title %q of open discussion", got)
+	}
+	if got := discussionThreadTitle(&answered); got != "✅ Help" {
+		t.Errorf("unexpected title %q of answered discussion", got)
+	}
+
+	if got, want := discussionTags(open), []string{"Q&A", "question"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected tags %q of open discussion, want %q", got, want)
+	}
+	if got, want := discussionTags(&answered), []string{"Q&A", "Answered", "question"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected tags %q of answered discussion, want %q", got, want)
+	}
+}
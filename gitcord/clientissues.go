@@ -3,8 +3,7 @@ package gitcord
 import (
 	"fmt"
 
-	"github.com/diamondburned/arikawa/v3/api"
-	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/ethanthatonekid/gitcord/gitcord/internal/discordclient"
 	"github.com/google/go-github/v47/github"
 	"github.com/pkg/errors"
 )
@@ -19,8 +18,9 @@ func (c *IssuesClient) logln(v ...any) {
 
 func (c *IssuesClient) OpenAndEmbedInitialMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
+	k := threadKey(ev.GetRepo(), issue.GetNumber())
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.ExistingThread(k)
 	if err == nil {
 		if !c.config.ForceOpen {
 			c.logln("issue", issue.GetNumber(), "already has a thread")
@@ -29,18 +29,18 @@ func (c *IssuesClient) OpenAndEmbedInitialMsg(ev *github.IssuesEvent) error {
 		c.logln(fmt.Sprintf("ignoring existing thread %d", t.ID))
 	}
 
-	t, err = c.discord.StartThreadWithoutMessage(c.config.DiscordChannelID, api.StartThreadData{
-		Name:                fmt.Sprintf("%d: %s", issue.GetNumber(), issue.GetTitle()),
-		Type:                discord.GuildPublicThread,
-		AutoArchiveDuration: discord.SevenDaysArchive,
+	t, msg, err := c.discord.OpenThread(discordclient.OpenThreadData{
+		Name:       (*client)(c).threadName(k, issue.GetTitle()),
+		Embed:      c.config.makeIssueEmbed(issue),
+		Tags:       labelNames(issue.Labels),
+		CreateTags: c.config.CreateForumTags,
 	})
 	if err != nil {
-		return errors.Wrap(err, "failed to open thread")
+		return err
 	}
 
-	_, err = c.discord.SendEmbeds(t.ID, c.config.makeIssueEmbed(issue))
-	if err != nil {
-		return errors.Wrap(err, "failed to send message")
+	if err := c.discord.RecordThread(k, t.ID, msg.ID); err != nil {
+		return errors.Wrap(err, "failed to record thread")
 	}
 
 	return nil
@@ -48,18 +48,19 @@ func (c *IssuesClient) OpenAndEmbedInitialMsg(ev *github.IssuesEvent) error {
 
 func (c *IssuesClient) EditInitialMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
+	k := threadKey(ev.GetRepo(), issue.GetNumber())
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(k)
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
 
-	msg := c.discord.FindMsgByIssue(t, issue.GetNumber())
+	msg := c.discord.FindInitialMsg(k, t, c.discord.FindMsgByIssue)
 	if msg == nil {
 		return fmt.Errorf("issue %d does not have an initial message", issue.GetNumber())
 	}
 
-	_, err = c.discord.EditEmbeds(t.ID, msg.ID, c.config.makeIssueEmbed(issue))
+	err = (*client)(c).editInitialEmbed(t.ID, msg.ID, c.config.makeIssueEmbed(issue))
 	if err != nil {
 		return errors.Wrap(err, "failed to edit message")
 	}
@@ -70,7 +71,7 @@ func (c *IssuesClient) EditInitialMsg(ev *github.IssuesEvent) error {
 func (c *IssuesClient) EmbedClosedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -86,7 +87,7 @@ func (c *IssuesClient) EmbedClosedMsg(ev *github.IssuesEvent) error {
 func (c *IssuesClient) EmbedReopenedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -102,7 +103,7 @@ func (c *IssuesClient) EmbedReopenedMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedDeletedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -118,7 +119,7 @@ func (c IssuesClient) EmbedDeletedMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedTransferredMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -134,7 +135,7 @@ func (c IssuesClient) EmbedTransferredMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedAssignedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -150,7 +151,7 @@ func (c IssuesClient) EmbedAssignedMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedUnassignedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -166,7 +167,7 @@ func (c IssuesClient) EmbedUnassignedMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedLabeledMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -182,7 +183,7 @@ func (c IssuesClient) EmbedLabeledMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedUnlabeledMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -198,7 +199,7 @@ func (c IssuesClient) EmbedUnlabeledMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedLockedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -214,7 +215,7 @@ func (c IssuesClient) EmbedLockedMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedUnlockedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -230,7 +231,7 @@ func (c IssuesClient) EmbedUnlockedMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedMilestonedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -246,7 +247,7 @@ func (c IssuesClient) EmbedMilestonedMsg(ev *github.IssuesEvent) error {
 func (c IssuesClient) EmbedDemilestonedMsg(ev *github.IssuesEvent) error {
 	issue := ev.GetIssue()
 
-	t, err := c.discord.FindThreadByNumber(issue.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
 	if err != nil {
 		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
 	}
@@ -258,3 +259,21 @@ func (c IssuesClient) EmbedDemilestonedMsg(ev *github.IssuesEvent) error {
 
 	return nil
 }
+
+// SyncForumTags applies the labels of the issue as tags to its thread if the
+// thread is a forum post.
+func (c IssuesClient) SyncForumTags(ev *github.IssuesEvent) error {
+	issue := ev.GetIssue()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), issue.GetNumber()))
+	if err != nil {
+		return fmt.Errorf("issue %d does not have a thread", issue.GetNumber())
+	}
+
+	err = c.discord.SetThreadTags(t, labelNames(issue.Labels), c.config.CreateForumTags)
+	if err != nil {
+		return errors.Wrap(err, "failed to set forum tags")
+	}
+
+	return nil
+}
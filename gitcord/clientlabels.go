@@ -0,0 +1,31 @@
+package gitcord
+
+import (
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// LabelsClient posts created, edited and deleted labels into the labels
+// channel.
+type LabelsClient client
+
+func (c *LabelsClient) logln(v ...any) {
+	prefixed := []any{"Labels:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+func (c *LabelsClient) EmbedLabelMsg(ev *github.LabelEvent) error {
+	ch := (*client)(c).feedChannel(c.config.Channels.Labels)
+	if !ch.IsValid() {
+		c.logln("no channel for label", ev.GetLabel().GetName())
+		return nil
+	}
+
+	_, err := c.discord.SendEmbeds(ch, c.config.makeLabelEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package gitcord
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// MilestonesClient posts the lifecycle of milestones into the milestones
+// channel.
+type MilestonesClient client
+
+func (c *MilestonesClient) logln(v ...any) {
+	prefixed := []any{"Milestones:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+func (c *MilestonesClient) EmbedMilestoneMsg(ev *github.MilestoneEvent) error {
+	embed := c.config.makeMilestoneEmbed(ev)
+
+	ch := (*client)(c).feedChannel(c.config.Channels.Milestones)
+	if !ch.IsValid() {
+		c.logln("no channel for", embed.Title)
+		return nil
+	}
+
+	_, err := c.discord.SendEmbeds(ch, embed)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+// EditRenamedMsgs edits the initial messages of the threads of the issues and
+// pull requests of a renamed milestone, whose Milestone field would otherwise
+// show its old title.
+func (c *MilestonesClient) EditRenamedMsgs(ev *github.MilestoneEvent) error {
+	if ev.GetChanges().GetTitle() == nil {
+		return nil
+	}
+
+	owner, name, err := splitRepo(ev.GetRepo())
+	if err != nil {
+		return err
+	}
+
+	opts := &github.IssueListByRepoOptions{
+		Milestone:   strconv.Itoa(ev.GetMilestone().GetNumber()),
+		State:       "all",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		issues, resp, err := c.github.Issues.ListByRepo(c.github.Context(), owner, name, opts)
+		if err != nil {
+			return errors.Wrap(err, "failed to list issues")
+		}
+
+		for _, issue := range issues {
+			if err := c.editInitialMsg(ev.GetRepo(), issue); err != nil {
+				c.logln(fmt.Sprintf("failed to edit initial message of #%d:", issue.GetNumber()), err)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (c *MilestonesClient) editInitialMsg(repo *github.Repository, issue *github.Issue) error {
+	k := threadKey(repo, issue.GetNumber())
+
+	t, err := c.discord.FindThread(k)
+	if err != nil {
+		// Not every issue has a thread.
+		return nil
+	}
+
+	findMsg := c.discord.FindMsgByIssue
+	var embed discord.Embed
+
+	if issue.IsPullRequest() {
+		owner, name, err := splitRepo(repo)
+		if err != nil {
+			return err
+		}
+
+		pr, _, err := c.github.PullRequests.Get(c.github.Context(), owner, name, issue.GetNumber())
+		if err != nil {
+			return errors.Wrap(err, "failed to get pull request")
+		}
+
+		findMsg = c.discord.FindMsgByPR
+		embed = c.config.makePREmbed(&github.PullRequestEvent{
+			PullRequest: pr,
+			Repo:        repo,
+			Sender:      pr.GetUser(),
+		})
+	} else {
+		embed = c.config.makeIssueEmbed(issue)
+	}
+
+	msg := c.discord.FindInitialMsg(k, t, findMsg)
+	if msg == nil {
+		return errors.New("no initial message")
+	}
+
+	err = (*client)(c).editInitialEmbed(t.ID, msg.ID, embed)
+	if err != nil {
+		return errors.Wrap(err, "failed to edit message")
+	}
+
+	return nil
+}
@@ -0,0 +1,327 @@
+package gitcord
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// ProjectsClient posts the moves of issues and pull requests on project boards
+// into their threads, and shows their status in the Project status field of
+// their initial messages.
+type ProjectsClient client
+
+func (c *ProjectsClient) logln(v ...any) {
+	prefixed := []any{"Projects:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+// projectStatusField is the name of the field of projects whose value is the
+// status of their items, and of the embed field showing it.
+const projectStatusField = "Status"
+
+// projectItem is an issue or pull request on a project board.
+type projectItem struct {
+	repo   *github.Repository
+	number int
+	// project and projectURL are the title and URL of the project.
+	project    string
+	projectURL string
+	// status is the status or column of the item, if any.
+	status string
+}
+
+// projectItemQuery looks up the content, project and status of a project item.
+const projectItemQuery = `query($id: ID!, $field: String!) {
+	node(id: $id) {
+		... on ProjectV2Item {
+			project { title url }
+			fieldValueByName(name: $field) {
+				... on ProjectV2ItemFieldSingleSelectValue {
+					name
+					field { ... on ProjectV2FieldCommon { id } }
+				}
+			}
+			content {
+				... on Issue { number repository { nameWithOwner } }
+				... on PullRequest { number repository { nameWithOwner } }
+			}
+		}
+	}
+}`
+
+// deletedItemQuery looks up the content and project of a deleted project
+// item, which can no longer be looked up itself.
+const deletedItemQuery = `query($content: ID!, $project: ID!) {
+	content: node(id: $content) {
+		... on Issue { number repository { nameWithOwner } }
+		... on PullRequest { number repository { nameWithOwner } }
+	}
+	project: node(id: $project) {
+		... on ProjectV2 { title }
+	}
+}`
+
+// EmbedItemMsg posts an issue or pull request being added to, moved within
+// or removed from a project into its thread, and updates the Project status
+// field of its initial message.
+func (c *ProjectsClient) EmbedItemMsg(ev *ProjectsV2ItemEvent) error {
+	if t := ev.GetItem().GetContentType(); t != "Issue" && t != "PullRequest" {
+		return nil
+	}
+
+	if ev.GetAction() == "deleted" {
+		item, err := c.deletedItem(ev.GetItem())
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			c.logln("content", ev.GetItem().GetContentNodeID(), "of deleted project item not found")
+			return nil
+		}
+
+		title := fmt.Sprintf("#%d removed from %s", item.number, item.project)
+		return c.sendItemMsg(*item, c.config.makeProjectItemEmbed(title, *item, ev.GetSender()))
+	}
+
+	var data struct {
+		Node *struct {
+			Project struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+			} `json:"project"`
+			FieldValue *struct {
+				Name  string `json:"name"`
+				Field struct {
+					ID string `json:"id"`
+				} `json:"field"`
+			} `json:"fieldValueByName"`
+			Content *struct {
+				Number     int `json:"number"`
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+			} `json:"content"`
+		} `json:"node"`
+	}
+
+	err := c.github.GraphQL(projectItemQuery, map[string]any{
+		"id":    ev.GetItem().GetNodeID(),
+		"field": projectStatusField,
+	}, &data)
+	if err != nil {
+		return errors.Wrap(err, "failed to look up project item")
+	}
+
+	if data.Node == nil || data.Node.Content == nil {
+		c.logln("project item", ev.GetItem().GetNodeID(), "not found")
+		return nil
+	}
+
+	repo := data.Node.Content.Repository.NameWithOwner
+	item := projectItem{
+		repo:       &github.Repository{FullName: &repo},
+		number:     data.Node.Content.Number,
+		project:    data.Node.Project.Title,
+		projectURL: data.Node.Project.URL,
+	}
+	if v := data.Node.FieldValue; v != nil {
+		item.status = v.Name
+	}
+
+	var title string
+	switch ev.GetAction() {
+	case "created":
+		title = fmt.Sprintf("#%d added to %s", item.number, item.project)
+	case "edited":
+		// Only changes of the status are moves.
+		if data.Node.FieldValue == nil || ev.GetChangedFieldNodeID() != data.Node.FieldValue.Field.ID {
+			return nil
+		}
+		title = fmt.Sprintf("#%d moved to %s", item.number, item.status)
+	case "archived":
+		title = fmt.Sprintf("#%d removed from %s", item.number, item.project)
+		item.status = ""
+		item.projectURL = ""
+	default:
+		return nil
+	}
+
+	return c.sendItemMsg(item, c.config.makeProjectItemEmbed(title, item, ev.GetSender()))
+}
+
+// deletedItem looks up the issue or pull request of a deleted project item
+// from the node IDs in the webhook payload. The returned item has no project
+// URL, so that its Project status field is removed. It returns nil if the
+// content no longer exists either.
+func (c *ProjectsClient) deletedItem(item *ProjectsV2Item) (*projectItem, error) {
+	var data struct {
+		Content *struct {
+			Number     int `json:"number"`
+			Repository struct {
+				NameWithOwner string `json:"nameWithOwner"`
+			} `json:"repository"`
+		} `json:"content"`
+		Project *struct {
+			Title string `json:"title"`
+		} `json:"project"`
+	}
+
+	err := c.github.GraphQL(deletedItemQuery, map[string]any{
+		"content": item.GetContentNodeID(),
+		"project": item.GetProjectNodeID(),
+	}, &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to look up deleted project item")
+	}
+
+	if data.Content == nil || data.Content.Number == 0 {
+		return nil, nil
+	}
+
+	repo := data.Content.Repository.NameWithOwner
+	deleted := &projectItem{
+		repo:    &github.Repository{FullName: &repo},
+		number:  data.Content.Number,
+		project: "a project",
+	}
+	if data.Project != nil && data.Project.Title != "" {
+		deleted.project = data.Project.Title
+	}
+
+	return deleted, nil
+}
+
+// EmbedCardMsg posts an issue or pull request being added to, moved between
+// the columns of or removed from a classic project into its thread, and
+// updates the Project status field of its initial message.
+func (c *ProjectsClient) EmbedCardMsg(ev *ProjectCardEvent) error {
+	card := ev.GetProjectCard()
+
+	// Cards of notes have no content.
+	repo, number, ok := parseIssueURL(card.GetContentURL())
+	if !ok {
+		return nil
+	}
+
+	column, _, err := c.github.Projects.GetProjectColumn(c.github.Context(), card.GetColumnID())
+	if err != nil {
+		return errors.Wrap(err, "failed to get project column")
+	}
+
+	projectID, err := strconv.ParseInt(path.Base(column.GetProjectURL()), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid project URL %q", column.GetProjectURL())
+	}
+
+	project, _, err := c.github.Projects.GetProject(c.github.Context(), projectID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get project")
+	}
+
+	item := projectItem{
+		repo:       &github.Repository{FullName: &repo},
+		number:     number,
+		project:    project.GetName(),
+		projectURL: project.GetHTMLURL(),
+		status:     column.GetName(),
+	}
+
+	var title string
+	switch ev.GetAction() {
+	case "created", "converted":
+		title = fmt.Sprintf("#%d added to %s", number, item.project)
+	case "moved":
+		// Cards moved within their column keep their status.
+		if !ev.GetColumnChanged() {
+			return nil
+		}
+		title = fmt.Sprintf("#%d moved to %s", number, item.status)
+	case "deleted":
+		title = fmt.Sprintf("#%d removed from %s", number, item.project)
+		item.status = ""
+		item.projectURL = ""
+	default:
+		return nil
+	}
+
+	return c.sendItemMsg(item, c.config.makeProjectItemEmbed(title, item, ev.GetSender()))
+}
+
+// sendItemMsg sends embed into the thread of item and sets the Project status
+// field of its initial message, or removes it if the item has no project.
+func (c *ProjectsClient) sendItemMsg(item projectItem, embed discord.Embed) error {
+	// Project events are routed by their organization or owner, which may
+	// not be the channel of the item's repository.
+	cc := (*client)(c)
+	if !cc.ruleChannel.IsValid() {
+		cc = cc.forChannel(c.config.channelFor(item.repo.GetFullName()))
+	}
+
+	k := threadKey(item.repo, item.number)
+	t, err := cc.discord.ExistingThread(k)
+	if err != nil {
+		c.logln("no thread for", k.String())
+		return nil
+	}
+
+	if _, err := cc.discord.SendEmbeds(t.ID, embed); err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	found := cc.discord.FindInitialMsg(k, t, func(ch *discord.Channel, n int) *discord.Message {
+		if msg := cc.discord.FindMsgByIssue(ch, n); msg != nil {
+			return msg
+		}
+		return cc.discord.FindMsgByPR(ch, n)
+	})
+	if found == nil {
+		c.logln("no initial message for", k.String())
+		return nil
+	}
+
+	// The found message may only be its ID.
+	msg, err := cc.discord.Message(t.ID, found.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get initial message")
+	}
+	if len(msg.Embeds) == 0 {
+		return nil
+	}
+
+	initial := msg.Embeds[0]
+	initial.Fields = withProjectStatus(initial.Fields, makeProjectStatusField(item))
+
+	if _, err := cc.discord.EditEmbeds(t.ID, msg.ID, initial); err != nil {
+		return errors.Wrap(err, "failed to edit initial message")
+	}
+
+	return nil
+}
+
+// parseIssueURL parses the API URL of an issue or pull request, e.g.
+// "https://api.github.com/repos/o/r/issues/12", into its repository and number.
+func parseIssueURL(url string) (repo string, number int, ok bool) {
+	_, rest, ok := strings.Cut(url, "/repos/")
+	if !ok {
+		return "", 0, false
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) != 4 || (parts[2] != "issues" && parts[2] != "pulls") {
+		return "", 0, false
+	}
+
+	number, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return "", 0, false
+	}
+
+	return parts[0] + "/" + parts[1], number, true
+}
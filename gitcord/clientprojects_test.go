@@ -0,0 +1,92 @@
+package gitcord
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ethanthatonekid/gitcord/gitcord/internal/githubclient"
+	"golang.org/x/oauth2"
+)
+
+func TestProjectsDeletedItem(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]string `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error("invalid request:", err)
+		}
+
+		// The deleted item itself can't be looked up anymore.
+		if req.Variables["content"] != "I_content" || req.Variables["project"] != "PVT_project" {
+			t.Errorf("unexpected variables %v", req.Variables)
+		}
+
+		io.WriteString(w, `{"data": {
+			"content": {"number": 12, "repository": {"nameWithOwner": "o/r"}},
+			"project": {"title": "Roadmap"}
+		}}`)
+	}))
+	defer srv.Close()
+
+	gh := githubclient.New(githubclient.Config{
+		OAuth:  oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		Logger: log.New(io.Discard, "", 0),
+	})
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	c := (*ProjectsClient)(&client{github: gh})
+
+	var ev ProjectsV2ItemEvent
+	err := json.Unmarshal([]byte(`{
+		"action": "deleted",
+		"projects_v2_item": {"node_id": "PVTI_item", "project_node_id": "PVT_project", "content_node_id": "I_content", "content_type": "Issue"}
+	}`), &ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := c.deletedItem(ev.GetItem())
+	if err != nil {
+		t.Fatal("failed to look up deleted item:", err)
+	}
+
+	if item == nil || item.repo.GetFullName() != "o/r" || item.number != 12 || item.project != "Roadmap" {
+		t.Fatalf("unexpected item %+v", item)
+	}
+
+	if field := makeProjectStatusField(*item); field != nil {
+		t.Errorf("deleted item keeps its Project status field %+v", field)
+	}
+}
+
+func TestParseIssueURL(t *testing.T) {
+	type test struct {
+		url    string
+		repo   string
+		number int
+		ok     bool
+	}
+
+	tests := []test{
+		{url: "https://api.github.com/repos/o/r/issues/12", repo: "o/r", number: 12, ok: true},
+		{url: "https://api.github.com/repos/o/r/pulls/13", repo: "o/r", number: 13, ok: true},
+		{url: ""},
+		{url: "https://api.github.com/repos/o/r/commits/abc"},
+		{url: "https://api.github.com/repos/o/r/issues/abc"},
+		{url: "https://api.github.com/repos/o/r/issues/12/comments"},
+		{url: "https://github.com/o/r/issues/12"},
+	}
+
+	for _, test := range tests {
+		repo, number, ok := parseIssueURL(test.url)
+		if repo != test.repo || number != test.number || ok != test.ok {
+			t.Errorf("parseIssueURL(%q) = %q, %d, %v, want %q, %d, %v", test.url, repo, number, ok, test.repo, test.number, test.ok)
+		}
+	}
+}
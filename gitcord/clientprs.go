@@ -2,9 +2,11 @@ package gitcord
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 
-	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/ethanthatonekid/gitcord/gitcord/internal/discordclient"
 	"github.com/google/go-github/v47/github"
 	"github.com/pkg/errors"
 )
@@ -45,8 +47,9 @@ func (c *PRsClient) logln(v ...any) {
 
 func (c *PRsClient) OpenAndEmbedInitialMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
+	k := threadKey(ev.GetRepo(), pr.GetNumber())
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.ExistingThread(k)
 	if err == nil {
 		if !c.config.ForceOpen {
 			return fmt.Errorf("pull request %d already has a thread %d", pr.GetNumber(), t.ID)
@@ -54,18 +57,18 @@ func (c *PRsClient) OpenAndEmbedInitialMsg(ev *github.PullRequestEvent) error {
 		c.logln(fmt.Sprintf("ignoring existing thread %d", t.ID))
 	}
 
-	t, err = c.discord.StartThreadWithoutMessage(c.config.DiscordChannelID, api.StartThreadData{
-		Name:                fmt.Sprintf("%d: %s", pr.GetNumber(), pr.GetTitle()),
-		Type:                discord.GuildPublicThread,
-		AutoArchiveDuration: discord.SevenDaysArchive,
+	t, msg, err := c.discord.OpenThread(discordclient.OpenThreadData{
+		Name:       (*client)(c).threadName(k, pr.GetTitle()),
+		Embed:      c.config.makePREmbed(ev),
+		Tags:       labelNames(pr.Labels),
+		CreateTags: c.config.CreateForumTags,
 	})
 	if err != nil {
-		return errors.Wrap(err, "failed to open thread")
+		return err
 	}
 
-	_, err = c.discord.SendEmbeds(t.ID, c.config.makePREmbed(ev))
-	if err != nil {
-		return errors.Wrap(err, "failed to send message")
+	if err := c.discord.RecordThread(k, t.ID, msg.ID); err != nil {
+		return errors.Wrap(err, "failed to record thread")
 	}
 
 	return nil
@@ -73,18 +76,19 @@ func (c *PRsClient) OpenAndEmbedInitialMsg(ev *github.PullRequestEvent) error {
 
 func (c *PRsClient) EditInitialMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
+	k := threadKey(ev.GetRepo(), pr.GetNumber())
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(k)
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
 
-	msg := c.discord.FindMsgByPR(t, pr.GetNumber())
+	msg := c.discord.FindInitialMsg(k, t, c.discord.FindMsgByPR)
 	if msg == nil {
 		return fmt.Errorf("pull request %d does not have an initial message", pr.GetNumber())
 	}
 
-	_, err = c.discord.EditEmbeds(t.ID, msg.ID, c.config.makePREmbed(ev))
+	err = (*client)(c).editInitialEmbed(t.ID, msg.ID, c.config.makePREmbed(ev))
 	if err != nil {
 		return errors.Wrap(err, "failed to edit message")
 	}
@@ -95,7 +99,7 @@ func (c *PRsClient) EditInitialMsg(ev *github.PullRequestEvent) error {
 func (c *PRsClient) EmbedClosedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -111,7 +115,7 @@ func (c *PRsClient) EmbedClosedMsg(ev *github.PullRequestEvent) error {
 func (c *PRsClient) EmbedReopenedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -127,7 +131,7 @@ func (c *PRsClient) EmbedReopenedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedDeletedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -143,7 +147,7 @@ func (c PRsClient) EmbedDeletedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedTransferredMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -159,7 +163,7 @@ func (c PRsClient) EmbedTransferredMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedAssignedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -175,7 +179,7 @@ func (c PRsClient) EmbedAssignedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedUnassignedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -191,7 +195,7 @@ func (c PRsClient) EmbedUnassignedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedLabeledMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -207,7 +211,7 @@ func (c PRsClient) EmbedLabeledMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedUnlabeledMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -223,7 +227,7 @@ func (c PRsClient) EmbedUnlabeledMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedLockedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -239,7 +243,7 @@ func (c PRsClient) EmbedLockedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedUnlockedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -255,7 +259,7 @@ func (c PRsClient) EmbedUnlockedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedMilestonedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -271,7 +275,7 @@ func (c PRsClient) EmbedMilestonedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedDemilestonedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -287,7 +291,7 @@ func (c PRsClient) EmbedDemilestonedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedReviewRequestedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -303,7 +307,7 @@ func (c PRsClient) EmbedReviewRequestedMsg(ev *github.PullRequestEvent) error {
 func (c PRsClient) EmbedReviewRequestRemovedMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -319,7 +323,7 @@ func (c PRsClient) EmbedReviewRequestRemovedMsg(ev *github.PullRequestEvent) err
 func (c PRsClient) EmbedReadyForReviewMsg(ev *github.PullRequestEvent) error {
 	pr := ev.GetPullRequest()
 
-	t, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -331,3 +335,126 @@ func (c PRsClient) EmbedReadyForReviewMsg(ev *github.PullRequestEvent) error {
 
 	return nil
 }
+
+// EmbedConvertedToDraftMsg posts the pull request being converted back to a
+// draft.
+func (c PRsClient) EmbedConvertedToDraftMsg(ev *github.PullRequestEvent) error {
+	return c.sendThreadEmbed(ev, c.config.makePRConvertedToDraftEmbed(ev))
+}
+
+// EmbedAutoMergeMsg posts auto-merge being enabled or disabled, with the
+// reason it was disabled.
+func (c PRsClient) EmbedAutoMergeMsg(ev *PullRequestEvent) error {
+	return c.sendThreadEmbed(&ev.PullRequestEvent, c.config.makePRAutoMergeEmbed(ev))
+}
+
+// EmbedMergeQueueMsg posts the pull request being added to or removed from the
+// merge queue, with the reason it was removed.
+func (c PRsClient) EmbedMergeQueueMsg(ev *PullRequestEvent) error {
+	return c.sendThreadEmbed(&ev.PullRequestEvent, c.config.makePRMergeQueueEmbed(ev))
+}
+
+// EmbedMergeGroupMsg posts the merge queue checking or destroying the merge
+// group of a pull request into its thread. Only the last pull request of the
+// group, which its head ref names, is notified, since the event does not list
+// the other pull requests of the group.
+func (c PRsClient) EmbedMergeGroupMsg(ev *MergeGroupEvent) error {
+	number, ok := mergeGroupPR(ev.GetMergeGroup().GetHeadRef())
+	if !ok {
+		c.logln("unknown merge group", ev.GetMergeGroup().GetHeadRef())
+		return nil
+	}
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), number))
+	if err != nil {
+		return &PRThreadError{PR: number, Err: err}
+	}
+
+	_, err = c.discord.SendEmbeds(t.ID, c.config.makeMergeGroupEmbed(ev, number))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+func (c PRsClient) sendThreadEmbed(ev *github.PullRequestEvent, embed discord.Embed) error {
+	pr := ev.GetPullRequest()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
+	if err != nil {
+		return &PRThreadError{PR: pr.GetNumber(), Err: err}
+	}
+
+	_, err = c.discord.SendEmbeds(t.ID, embed)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+// mergeGroupPRRe matches the branch of a merge group, whose last pull request
+// is the one it was created for.
+var mergeGroupPRRe = regexp.MustCompile(`/pr-(\d+)-[0-9a-f]+$`)
+
+// mergeGroupPR returns the number of the pull request that the merge group
+// with the head ref was created for, which is the last pull request of the
+// group. Other pull requests of the group cannot be told from the head ref.
+func mergeGroupPR(headRef string) (int, bool) {
+	m := mergeGroupPRRe.FindStringSubmatch(headRef)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	return n, err == nil
+}
+
+// SyncForumTags applies the labels of the pull request as tags to its thread if the
+// thread is a forum post.
+func (c PRsClient) SyncForumTags(ev *github.PullRequestEvent) error {
+	pr := ev.GetPullRequest()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
+	if err != nil {
+		return &PRThreadError{PR: pr.GetNumber(), Err: err}
+	}
+
+	err = c.discord.SetThreadTags(t, labelNames(pr.Labels), c.config.CreateForumTags)
+	if err != nil {
+		return errors.Wrap(err, "failed to set forum tags")
+	}
+
+	return nil
+}
+
+// EmbedSynchronizedMsg posts the commits pushed to the pull request since its
+// previous head into its thread.
+func (c *PRsClient) EmbedSynchronizedMsg(ev *github.PullRequestEvent) error {
+	pr := ev.GetPullRequest()
+
+	t, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
+	if err != nil {
+		return &PRThreadError{PR: pr.GetNumber(), Err: err}
+	}
+
+	comparison, _, err := c.github.Repositories.CompareCommits(
+		c.github.Context(),
+		ev.GetRepo().GetOwner().GetLogin(), ev.GetRepo().GetName(),
+		ev.GetBefore(), ev.GetAfter(),
+		&github.ListOptions{PerPage: maxPushCommits + 1},
+	)
+	if err != nil {
+		// The previous head may be gone after a force push, in which case
+		// the embed is sent without the new commits.
+		c.logln("failed to compare", ev.GetBefore(), "with", ev.GetAfter()+":", err)
+		comparison = nil
+	}
+
+	_, err = c.discord.SendEmbeds(t.ID, c.config.makePRSynchronizedEmbed(ev, comparison))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package gitcord
+
+import "testing"
+
+func TestMergeGroupPR(t *testing.T) {
+	type test struct {
+		ref    string
+		number int
+		ok     bool
+	}
+
+	tests := []test{
+		{ref: "refs/heads/gh-readonly-queue/main/pr-12-2f4a6d8c", number: 12, ok: true},
+		{ref: "gh-readonly-queue/release/v1/pr-7-0123456789abcdef", number: 7, ok: true},
+		{ref: "refs/heads/main"},
+		{ref: "refs/heads/gh-readonly-queue/main/pr-12"},
+		{ref: "refs/heads/gh-readonly-queue/main/pr-x-2f4a6d8c"},
+		{ref: "refs/heads/gh-readonly-queue/main/pr-12-XYZ"},
+		{ref: ""},
+	}
+
+	for _, test := range tests {
+		number, ok := mergeGroupPR(test.ref)
+		if number != test.number || ok != test.ok {
+			t.Errorf("mergeGroupPR(%q) = %d, %v, want %d, %v", test.ref, number, ok, test.number, test.ok)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package gitcord
+
+import (
+	"strings"
+
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// PushesClient posts pushes to branches without an open pull request into the
+// pushes channel. Pushes to pull requests are left to their synchronize events,
+// which are posted into their threads.
+type PushesClient client
+
+func (c *PushesClient) logln(v ...any) {
+	prefixed := []any{"Pushes:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+// EmbedPushMsg posts an embed listing the pushed commits. Deleted branches and
+// pushed tags are left to CreateEvent and DeleteEvent.
+func (c *PushesClient) EmbedPushMsg(ev *github.PushEvent) error {
+	if !strings.HasPrefix(ev.GetRef(), "refs/heads/") || ev.GetDeleted() {
+		return nil
+	}
+
+	if c.hasPRThread(ev) {
+		c.logln("leaving push to", ev.GetRef(), "to its pull request")
+		return nil
+	}
+
+	ch := (*client)(c).feedChannel(c.config.Channels.Pushes)
+	if !ch.IsValid() {
+		c.logln("no channel for push to", ev.GetRef())
+		return nil
+	}
+
+	_, err := c.discord.SendEmbeds(ch, c.config.makePushEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+// hasPRThread returns true if the pushed branch is the head of an open pull
+// request with a thread, which gets the push through its synchronize event.
+func (c *PushesClient) hasPRThread(ev *github.PushEvent) bool {
+	branch := strings.TrimPrefix(ev.GetRef(), "refs/heads/")
+
+	pr, err := c.branchPR(ev.GetRepo(), branch)
+	if err != nil {
+		c.logln("failed to find pull request of", branch+":", err)
+	}
+
+	if pr == nil {
+		return false
+	}
+
+	k := store.Key{Repo: ev.GetRepo().GetFullName(), Number: pr.GetNumber()}
+	_, err = c.discord.ExistingThread(k)
+	return err == nil
+}
+
+// branchPR returns the open pull request whose head is branch within repo, or
+// nil if there is none. Only pull requests from repo itself are matched: pushes
+// to the branch of a pull request from a fork are pushes to the fork, which
+// are never delivered to the webhooks of repo, so they reach the thread
+// through the synchronize event alone.
+func (c *PushesClient) branchPR(repo *github.PushEventRepository, branch string) (*github.PullRequest, error) {
+	owner, name, ok := strings.Cut(repo.GetFullName(), "/")
+	if !ok {
+		return nil, errors.Errorf("invalid repository %q", repo.GetFullName())
+	}
+
+	prs, _, err := c.github.PullRequests.List(c.github.Context(), owner, name, &github.PullRequestListOptions{
+		State: "open",
+		Head:  owner + ":" + branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prs) == 0 {
+		return nil, nil
+	}
+
+	return prs[0], nil
+}
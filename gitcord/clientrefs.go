@@ -0,0 +1,44 @@
+package gitcord
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// RefsClient posts the creation and deletion of branches and tags into the
+// refs channel.
+type RefsClient client
+
+func (c *RefsClient) logln(v ...any) {
+	prefixed := []any{"Refs:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+func (c *RefsClient) EmbedCreatedMsg(ev *github.CreateEvent) error {
+	return c.sendEmbed(ev.GetRefType(), ev.GetRef(), c.config.makeRefCreatedEmbed(ev))
+}
+
+func (c *RefsClient) EmbedDeletedMsg(ev *github.DeleteEvent) error {
+	return c.sendEmbed(ev.GetRefType(), ev.GetRef(), c.config.makeRefDeletedEmbed(ev))
+}
+
+func (c *RefsClient) sendEmbed(refType, ref string, embed discord.Embed) error {
+	if !c.config.Refs.Matches(refType, ref) {
+		return nil
+	}
+
+	ch := (*client)(c).feedChannel(c.config.Channels.Refs)
+	if !ch.IsValid() {
+		c.logln("no channel for", refType, ref)
+		return nil
+	}
+
+	_, err := c.discord.SendEmbeds(ch, embed)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
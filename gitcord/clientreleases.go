@@ -0,0 +1,110 @@
+package gitcord
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// ReleasesClient announces releases in the releases channel.
+type ReleasesClient client
+
+func (c *ReleasesClient) logln(v ...any) {
+	prefixed := []any{"Releases:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+// EmbedReleaseMsg announces a published release. GitHub delivers both a
+// published and a prereleased event for pre-releases, so a release that was
+// already announced has its announcement edited instead.
+func (c *ReleasesClient) EmbedReleaseMsg(ev *github.ReleaseEvent) error {
+	if ev.GetRelease().GetDraft() {
+		return nil
+	}
+
+	k := releaseMsgKey(ev)
+	if _, err := c.store.Message(k); err == nil {
+		return c.EditReleaseMsg(ev)
+	}
+
+	ch := (*client)(c).feedChannel(c.config.Channels.Releases)
+	if !ch.IsValid() {
+		c.logln("no channel for release", ev.GetRelease().GetTagName())
+		return nil
+	}
+
+	msg, err := c.discord.SendEmbedsFor(k, ch, c.config.makeReleaseEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	if c.config.Channels.CrosspostReleases {
+		c.crosspost(msg)
+	}
+
+	return nil
+}
+
+// EditReleaseMsg edits the announcement of an edited release, if it was
+// announced.
+func (c *ReleasesClient) EditReleaseMsg(ev *github.ReleaseEvent) error {
+	m, err := c.store.Message(releaseMsgKey(ev))
+	if err != nil {
+		c.logln("no announcement of release", ev.GetRelease().GetTagName())
+		return nil
+	}
+
+	_, err = c.discord.EditEmbeds(m.ChannelID, m.MessageID, c.config.makeReleaseEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to edit message")
+	}
+
+	return nil
+}
+
+// EmbedReleaseDeletedMsg replaces the announcement of a deleted release, if it
+// was announced, with a notice of its deletion.
+func (c *ReleasesClient) EmbedReleaseDeletedMsg(ev *github.ReleaseEvent) error {
+	k := releaseMsgKey(ev)
+
+	m, err := c.store.Message(k)
+	if err != nil {
+		c.logln("no announcement of release", ev.GetRelease().GetTagName())
+		return nil
+	}
+
+	_, err = c.discord.EditEmbeds(m.ChannelID, m.MessageID, c.config.makeReleaseDeletedEmbed(ev))
+	if err != nil {
+		return errors.Wrap(err, "failed to edit message")
+	}
+
+	if err := c.store.DeleteMessage(k); err != nil {
+		c.logln("failed to forget announcement of release", ev.GetRelease().GetTagName()+":", err)
+	}
+
+	return nil
+}
+
+// crosspost publishes msg to the servers following its channel, if it is an
+// announcement channel.
+func (c *ReleasesClient) crosspost(msg *discord.Message) {
+	ch, err := c.discord.Channel(msg.ChannelID)
+	if err != nil {
+		c.logln("failed to get channel of announcement:", err)
+		return
+	}
+
+	if ch.Type != discord.GuildNews {
+		return
+	}
+
+	if _, err := c.discord.CrosspostMessage(msg.ChannelID, msg.ID); err != nil {
+		c.logln("failed to crosspost announcement:", err)
+	}
+}
+
+func releaseMsgKey(ev *github.ReleaseEvent) store.MessageKey {
+	return store.MessageKey{Kind: store.ReleaseMsg, ID: ev.GetRelease().GetID()}
+}
@@ -0,0 +1,123 @@
+package gitcord
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// replyMarkerRe matches the hidden marker that is appended to GitHub comments
+// posted from Discord. It lets the comment's webhook echo be recognized even
+// if the store has not recorded it yet.
+var replyMarkerRe = regexp.MustCompile(`<!-- gitcord:discord:(\d+) -->`)
+
+// RepliesClient posts messages sent in gitcord threads back to their issue or
+// pull request as GitHub comments.
+type RepliesClient client
+
+func (c *RepliesClient) logln(v ...any) {
+	prefixed := []any{"Replies:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+// PostCommentMsg posts msg as a comment on the issue or pull request linked to
+// the thread it was sent in. Messages sent by bots, messages in discussion
+// threads and messages outside of gitcord threads are ignored.
+func (c *RepliesClient) PostCommentMsg(msg *discord.Message) error {
+	if msg.Author.Bot || msg.WebhookID.IsValid() {
+		return nil
+	}
+
+	if msg.Type != discord.DefaultMessage && msg.Type != discord.InlinedReplyMessage {
+		return nil
+	}
+
+	k, err := c.store.ThreadKey(msg.ChannelID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to look up thread")
+	}
+
+	if (*client)(c).isDiscussion(k) {
+		c.logln("ignoring message", msg.ID, "in the thread of discussion", k)
+		return nil
+	}
+
+	body := makeReplyCommentBody(msg)
+	if body == "" {
+		return nil
+	}
+
+	owner, repo, ok := k.SplitRepo()
+	if !ok {
+		return fmt.Errorf("invalid repository %q", k.Repo)
+	}
+
+	gh, err := c.github.ForRepo(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	comment, _, err := gh.Issues.CreateComment(gh.Context(), owner, repo, k.Number, &github.IssueComment{
+		Body: &body,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to comment on %s", k)
+	}
+
+	c.logln("posted message", msg.ID, "as comment", comment.GetID(), "on", k)
+
+	err = c.store.SetMessage(
+		store.MessageKey{Kind: store.IssueCommentMsg, ID: comment.GetID()},
+		store.Message{ChannelID: msg.ChannelID, MessageID: msg.ID, Reply: true},
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to record comment")
+	}
+
+	return nil
+}
+
+// makeReplyCommentBody formats msg as the body of a GitHub comment. It returns
+// an empty string if msg has nothing to post.
+func makeReplyCommentBody(msg *discord.Message) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSpace(msg.Content))
+
+	for _, a := range msg.Attachments {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "[%s](%s)", a.Filename, a.URL)
+	}
+
+	if b.Len() == 0 {
+		return ""
+	}
+
+	// The username is put in a code span so that it does not mention an
+	// unrelated GitHub user with the same name.
+	fmt.Fprintf(&b, "\n\n<sub>via Discord by `@%s`</sub>", msg.Author.Username)
+	fmt.Fprintf(&b, "\n<!-- gitcord:discord:%d -->", msg.ID)
+
+	return b.String()
+}
+
+// isReplyComment returns true if comment was posted from Discord by
+// RepliesClient, in which case it must not be mirrored back into Discord.
+func (c *client) isReplyComment(comment *github.IssueComment) bool {
+	if replyMarkerRe.MatchString(comment.GetBody()) {
+		return true
+	}
+
+	m, err := c.store.Message(store.MessageKey{Kind: store.IssueCommentMsg, ID: comment.GetID()})
+	return err == nil && m.Reply
+}
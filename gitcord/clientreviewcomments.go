@@ -3,6 +3,7 @@ package gitcord
 import (
 	"fmt"
 
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
 	"github.com/google/go-github/v47/github"
 	"github.com/pkg/errors"
 )
@@ -12,12 +13,14 @@ type ReviewCommentsClient client
 func (c ReviewCommentsClient) EmbedReviewCommentMsg(ev *github.PullRequestReviewCommentEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
 
-	_, err = c.discord.SendEmbeds(ch.ID, c.config.makePRReviewCommentEmbed(ev))
+	k := store.MessageKey{Kind: store.ReviewCommentMsg, ID: ev.GetComment().GetID()}
+
+	_, err = c.discord.SendEmbedsFor(k, ch.ID, c.config.makePRReviewCommentEmbed(ev))
 	if err != nil {
 		return errors.Wrap(err, "failed to send message")
 	}
@@ -28,12 +31,12 @@ func (c ReviewCommentsClient) EmbedReviewCommentMsg(ev *github.PullRequestReview
 func (c ReviewCommentsClient) EditReviewCommentMsg(ev *github.PullRequestReviewCommentEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
 
-	msg := c.discord.FindMsgByComment(ch, ev.GetComment().GetID())
+	msg := c.discord.FindMsg(store.MessageKey{Kind: store.ReviewCommentMsg, ID: ev.GetComment().GetID()}, ch)
 	if msg == nil {
 		return fmt.Errorf("failed to find message")
 	}
@@ -49,7 +52,7 @@ func (c ReviewCommentsClient) EditReviewCommentMsg(ev *github.PullRequestReviewC
 func (c ReviewCommentsClient) EmbedReviewCommentDeletedMsg(ev *github.PullRequestReviewCommentEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -3,6 +3,7 @@ package gitcord
 import (
 	"fmt"
 
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
 	"github.com/google/go-github/v47/github"
 	"github.com/pkg/errors"
 )
@@ -12,12 +13,14 @@ type ReviewsClient client
 func (c *ReviewsClient) EmbedReviewMsg(ev *github.PullRequestReviewEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
 
-	_, err = c.discord.SendEmbeds(ch.ID, c.config.makePRReviewEmbed(ev))
+	k := store.MessageKey{Kind: store.ReviewMsg, ID: ev.GetReview().GetID()}
+
+	_, err = c.discord.SendEmbedsFor(k, ch.ID, c.config.makePRReviewEmbed(ev))
 	if err != nil {
 		return errors.Wrap(err, "failed to send message")
 	}
@@ -28,7 +31,7 @@ func (c *ReviewsClient) EmbedReviewMsg(ev *github.PullRequestReviewEvent) error
 func (c ReviewsClient) EmbedReviewDismissedMsg(ev *github.PullRequestReviewEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -44,12 +47,12 @@ func (c ReviewsClient) EmbedReviewDismissedMsg(ev *github.PullRequestReviewEvent
 func (c ReviewsClient) EditReviewMsg(ev *github.PullRequestReviewEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
 
-	msg := c.discord.FindMsgByComment(ch, ev.GetReview().GetID())
+	msg := c.discord.FindMsg(store.MessageKey{Kind: store.ReviewMsg, ID: ev.GetReview().GetID()}, ch)
 	if msg == nil {
 		return fmt.Errorf("failed to find message")
 	}
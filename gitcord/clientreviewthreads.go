@@ -3,6 +3,7 @@ package gitcord
 import (
 	"fmt"
 
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
 	"github.com/google/go-github/v47/github"
 	"github.com/pkg/errors"
 )
@@ -12,12 +13,14 @@ type ReviewThreadsClient client
 func (c ReviewThreadsClient) EmbedReviewThreadMsg(ev *github.PullRequestReviewThreadEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
 
-	_, err = c.discord.SendEmbeds(ch.ID, c.config.makePRReviewThreadEmbed(ev))
+	k := store.MessageKey{Kind: store.ReviewThreadMsg, ID: ev.GetThread().GetID()}
+
+	_, err = c.discord.SendEmbedsFor(k, ch.ID, c.config.makePRReviewThreadEmbed(ev))
 	if err != nil {
 		return errors.Wrap(err, "failed to send message")
 	}
@@ -28,12 +31,12 @@ func (c ReviewThreadsClient) EmbedReviewThreadMsg(ev *github.PullRequestReviewTh
 func (c ReviewThreadsClient) EditReviewThreadMsg(ev *github.PullRequestReviewThreadEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
 
-	msg := c.discord.FindMsgByComment(ch, ev.GetThread().GetID())
+	msg := c.discord.FindMsg(store.MessageKey{Kind: store.ReviewThreadMsg, ID: ev.GetThread().GetID()}, ch)
 	if msg == nil {
 		return fmt.Errorf("failed to find message")
 	}
@@ -49,7 +52,7 @@ func (c ReviewThreadsClient) EditReviewThreadMsg(ev *github.PullRequestReviewThr
 func (c ReviewThreadsClient) EmbedReviewThreadResolvedMsg(ev *github.PullRequestReviewThreadEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
@@ -65,7 +68,7 @@ func (c ReviewThreadsClient) EmbedReviewThreadResolvedMsg(ev *github.PullRequest
 func (c ReviewThreadsClient) EmbedReviewThreadUnresolvedMsg(ev *github.PullRequestReviewThreadEvent) error {
 	pr := ev.GetPullRequest()
 
-	ch, err := c.discord.FindThreadByNumber(pr.GetNumber())
+	ch, err := c.discord.FindThread(threadKey(ev.GetRepo(), pr.GetNumber()))
 	if err != nil {
 		return &PRThreadError{PR: pr.GetNumber(), Err: err}
 	}
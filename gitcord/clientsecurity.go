@@ -0,0 +1,50 @@
+package gitcord
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// SecurityClient posts Dependabot, code scanning and secret scanning alerts
+// and security advisories into the security channel.
+type SecurityClient client
+
+func (c *SecurityClient) logln(v ...any) {
+	prefixed := []any{"Security:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+func (c *SecurityClient) EmbedDependabotAlertMsg(ev *DependabotAlertEvent) error {
+	return c.sendEmbed(c.config.makeDependabotAlertEmbed(ev))
+}
+
+func (c *SecurityClient) EmbedCodeScanningAlertMsg(ev *github.CodeScanningAlertEvent) error {
+	return c.sendEmbed(c.config.makeCodeScanningAlertEmbed(ev))
+}
+
+// EmbedSecretScanningAlertMsg posts a secret scanning alert. The secret itself
+// is never posted.
+func (c *SecurityClient) EmbedSecretScanningAlertMsg(ev *github.SecretScanningAlertEvent) error {
+	return c.sendEmbed(c.config.makeSecretScanningAlertEmbed(ev))
+}
+
+func (c *SecurityClient) EmbedSecurityAdvisoryMsg(ev *SecurityAdvisoryEvent) error {
+	return c.sendEmbed(c.config.makeSecurityAdvisoryEmbed(ev))
+}
+
+func (c *SecurityClient) sendEmbed(embed discord.Embed) error {
+	ch := (*client)(c).feedChannel(c.config.Channels.Security)
+	if !ch.IsValid() {
+		c.logln("no channel for", embed.Title)
+		return nil
+	}
+
+	_, err := c.discord.SendEmbeds(ch, embed)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
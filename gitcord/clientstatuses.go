@@ -0,0 +1,33 @@
+package gitcord
+
+import (
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// StatusesClient posts commit statuses into the thread of the pull request
+// whose head they are about, or else into the deployments channel, where
+// commit statuses of deployment tools usually belong.
+type StatusesClient client
+
+func (c *StatusesClient) logln(v ...any) {
+	prefixed := []any{"Statuses:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+func (c *StatusesClient) EmbedStatusMsg(ev *github.StatusEvent) error {
+	chs := (*client)(c).shaChannels(ev.GetRepo(), ev.GetSHA(), c.config.Channels.Deployments)
+	if len(chs) == 0 {
+		c.logln("no channel for status of", shortSHA(ev.GetSHA()))
+		return nil
+	}
+
+	for _, ch := range chs {
+		if _, err := c.discord.SendEmbeds(ch, c.config.makeStatusEmbed(ev)); err != nil {
+			return errors.Wrap(err, "failed to send message")
+		}
+	}
+
+	return nil
+}
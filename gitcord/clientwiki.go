@@ -0,0 +1,120 @@
+package gitcord
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"os/exec"
+
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+)
+
+// WikiClient posts changes to wiki pages into the wiki channel.
+type WikiClient client
+
+func (c *WikiClient) logln(v ...any) {
+	prefixed := []any{"Wiki:"}
+	prefixed = append(prefixed, v...)
+	c.config.Logger.Println(prefixed...)
+}
+
+// EmbedWikiMsg posts a summary of the pages changed by a push to the wiki,
+// followed by their diffs if Channels.WikiDiffs is set.
+func (c *WikiClient) EmbedWikiMsg(ev *github.GollumEvent) error {
+	ch := (*client)(c).feedChannel(c.config.Channels.Wiki)
+	if !ch.IsValid() {
+		c.logln("no channel for wiki of", ev.GetRepo().GetFullName())
+		return nil
+	}
+
+	var diffs map[*github.Page]string
+	if c.config.Channels.WikiDiffs {
+		var err error
+		diffs, err = c.pageDiffs(ev)
+		if err != nil {
+			c.logln("failed to get diffs of", ev.GetRepo().GetFullName()+":", err)
+		}
+	}
+
+	_, err := c.discord.SendEmbeds(ch, c.config.makeWikiEmbeds(ev, diffs)...)
+	if err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return nil
+}
+
+// pageDiffs fetches the commits of the changed pages from the wiki's Git
+// repository and returns the diff of each page. It requires git.
+func (c *WikiClient) pageDiffs(ev *github.GollumEvent) (map[*github.Page]string, error) {
+	dir, err := os.MkdirTemp("", "gitcord-wiki-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create directory")
+	}
+	defer os.RemoveAll(dir)
+
+	// Basic authentication with any username works for both personal and
+	// installation access tokens.
+	token, err := c.github.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get token")
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token.AccessToken))
+
+	// The token is passed in the environment rather than the arguments, which
+	// other users of the machine can see.
+	env := []string{
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic " + auth,
+	}
+
+	fetch := []string{"fetch", "--quiet", "--depth=2", ev.GetRepo().GetHTMLURL() + ".wiki.git"}
+
+	seen := make(map[string]bool)
+	for _, page := range ev.Pages {
+		if !seen[page.GetSHA()] {
+			seen[page.GetSHA()] = true
+			fetch = append(fetch, page.GetSHA())
+		}
+	}
+
+	if _, err := c.git(dir, nil, "init", "--quiet", "--bare"); err != nil {
+		return nil, err
+	}
+	if _, err := c.git(dir, env, fetch...); err != nil {
+		return nil, err
+	}
+
+	diffs := make(map[*github.Page]string, len(ev.Pages))
+	for _, page := range ev.Pages {
+		// Wiki pages are files named after the page with the extension of
+		// their markup language, in any directory.
+		diff, err := c.git(dir, nil, "show", "--format=", "--no-color", page.GetSHA(), "--", ":(glob)**/"+page.GetPageName()+".*")
+		if err != nil {
+			return nil, err
+		}
+		diffs[page] = diff
+	}
+
+	return diffs, nil
+}
+
+// git runs git in dir with the environment variables env added.
+func (c *WikiClient) git(dir string, env []string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(c.github.Context(), "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "git %s: %s", args[0], bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,210 @@
+package gitcord
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/pkg/errors"
+)
+
+// commands are the slash commands registered by Gateway. They act on the issue
+// or pull request linked to the thread that they are used in.
+var commands = []api.CreateCommandData{
+	{
+		Name:        string(CloseAction),
+		Description: "Close the linked issue or pull request",
+		Options: discord.CommandOptions{
+			&discord.StringOption{
+				OptionName:  "reason",
+				Description: "Reason for closing an issue",
+				Choices: []discord.StringChoice{
+					{Name: "completed", Value: "completed"},
+					{Name: "not planned", Value: "not_planned"},
+				},
+			},
+		},
+	},
+	{
+		Name:        string(ReopenAction),
+		Description: "Reopen the linked issue or pull request",
+	},
+	{
+		Name:        string(LabelAction),
+		Description: "Label the linked issue or pull request",
+		Options: discord.CommandOptions{
+			&discord.SubcommandOption{
+				OptionName:  "add",
+				Description: "Add a label",
+				Options: []discord.CommandOptionValue{
+					&discord.StringOption{OptionName: "name", Description: "Label name", Required: true},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:  "remove",
+				Description: "Remove a label",
+				Options: []discord.CommandOptionValue{
+					&discord.StringOption{OptionName: "name", Description: "Label name", Required: true},
+				},
+			},
+		},
+	},
+	{
+		Name:        string(AssignAction),
+		Description: "Assign a GitHub user to the linked issue or pull request",
+		Options: discord.CommandOptions{
+			&discord.StringOption{OptionName: "user", Description: "GitHub username", Required: true},
+		},
+	},
+	{
+		Name:        string(RequestReviewAction),
+		Description: "Request a review from a GitHub user on the linked pull request",
+		Options: discord.CommandOptions{
+			&discord.StringOption{OptionName: "reviewer", Description: "GitHub username", Required: true},
+		},
+	},
+	{
+		Name:        string(LockAction),
+		Description: "Lock the conversation of the linked issue or pull request",
+		Options: discord.CommandOptions{
+			&discord.StringOption{
+				OptionName:  "reason",
+				Description: "Reason for locking",
+				Choices: []discord.StringChoice{
+					{Name: "off-topic", Value: "off-topic"},
+					{Name: "too heated", Value: "too heated"},
+					{Name: "resolved", Value: "resolved"},
+					{Name: "spam", Value: "spam"},
+				},
+			},
+		},
+	},
+}
+
+// commandFunc takes a slash command action on the issue or pull request k and
+// returns a message describing what was done.
+type commandFunc func(c *Client, k store.Key, opts discord.CommandInteractionOptions) (string, error)
+
+func (g *Gateway) newRouter() *cmdroute.Router {
+	r := cmdroute.NewRouter()
+
+	// Commands call GitHub, which may take longer than the 3 seconds Discord
+	// waits for a response, so slow ones are deferred and followed up on.
+	r.Use(cmdroute.Deferrable(g.session, cmdroute.DeferOpts{
+		Flags: discord.EphemeralMessage,
+		Error: func(err error) {
+			g.logln("failed to follow up on command:", err)
+		},
+	}))
+
+	r.AddFunc(string(CloseAction), g.command(CloseAction, func(c *Client, k store.Key, opts discord.CommandInteractionOptions) (string, error) {
+		return fmt.Sprintf("Closed %s.", k), c.Commands.Close(k, opts.Find("reason").String())
+	}))
+
+	r.AddFunc(string(ReopenAction), g.command(ReopenAction, func(c *Client, k store.Key, opts discord.CommandInteractionOptions) (string, error) {
+		return fmt.Sprintf("Reopened %s.", k), c.Commands.Reopen(k)
+	}))
+
+	r.Sub(string(LabelAction), func(r *cmdroute.Router) {
+		r.AddFunc("add", g.command(LabelAction, func(c *Client, k store.Key, opts discord.CommandInteractionOptions) (string, error) {
+			label := opts.Find("name").String()
+			return fmt.Sprintf("Added label %q to %s.", label, k), c.Commands.AddLabel(k, label)
+		}))
+		r.AddFunc("remove", g.command(LabelAction, func(c *Client, k store.Key, opts discord.CommandInteractionOptions) (string, error) {
+			label := opts.Find("name").String()
+			return fmt.Sprintf("Removed label %q from %s.", label, k), c.Commands.RemoveLabel(k, label)
+		}))
+	})
+
+	r.AddFunc(string(AssignAction), g.command(AssignAction, func(c *Client, k store.Key, opts discord.CommandInteractionOptions) (string, error) {
+		user := opts.Find("user").String()
+		return fmt.Sprintf("Assigned %s to %s.", user, k), c.Commands.Assign(k, user)
+	}))
+
+	r.AddFunc(string(RequestReviewAction), g.command(RequestReviewAction, func(c *Client, k store.Key, opts discord.CommandInteractionOptions) (string, error) {
+		reviewer := opts.Find("reviewer").String()
+		return fmt.Sprintf("Requested a review from %s on %s.", reviewer, k), c.Commands.RequestReview(k, reviewer)
+	}))
+
+	r.AddFunc(string(LockAction), g.command(LockAction, func(c *Client, k store.Key, opts discord.CommandInteractionOptions) (string, error) {
+		return fmt.Sprintf("Locked %s.", k), c.Commands.Lock(k, opts.Find("reason").String())
+	}))
+
+	return r
+}
+
+// command wraps f into a slash command handler that checks the permissions of
+// the invoking member and finds the issue or pull request linked to the thread
+// that the command was used in.
+func (g *Gateway) command(action CommandAction, f commandFunc) cmdroute.CommandHandlerFunc {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		ev := data.Event
+		c := g.client.WithContext(ctx)
+
+		if ev.Member == nil || !c.client.config.CommandPermissions.Allows(action, ev.Member.RoleIDs) {
+			return ephemeralf("You are not allowed to use /%s.", action)
+		}
+
+		k, err := c.client.store.ThreadKey(ev.ChannelID)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return ephemeralf("This thread is not linked to an issue or pull request.")
+			}
+			g.logln("failed to look up thread", ev.ChannelID, "for command:", err)
+			return ephemeralf("Failed to look up the linked issue or pull request.")
+		}
+
+		if c.client.isDiscussion(k) {
+			return ephemeralf("/%s can't be used in the thread of a discussion.", action)
+		}
+
+		msg, err := f(c, k, data.Options)
+		if err != nil {
+			g.logln("/"+string(action), "failed:", err)
+			return ephemeralf("Failed to %s %s: %v", action, k, errors.Cause(err))
+		}
+
+		return ephemeralf("%s", msg)
+	}
+}
+
+// registerCommands registers the slash commands in the guilds of the parent
+// channels.
+func (g *Gateway) registerCommands() error {
+	app, err := g.session.CurrentApplication()
+	if err != nil {
+		return errors.Wrap(err, "failed to get application")
+	}
+
+	registered := make(map[discord.GuildID]bool)
+	for _, id := range g.client.client.config.channelIDs() {
+		ch, err := g.session.Channel(id)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get parent channel %d", id)
+		}
+
+		if registered[ch.GuildID] {
+			continue
+		}
+
+		_, err = g.session.BulkOverwriteGuildCommands(app.ID, ch.GuildID, commands)
+		if err != nil {
+			return errors.Wrapf(err, "failed to register commands in guild %d", ch.GuildID)
+		}
+
+		registered[ch.GuildID] = true
+	}
+
+	return nil
+}
+
+func ephemeralf(f string, v ...any) *api.InteractionResponseData {
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(fmt.Sprintf(f, v...)),
+		Flags:   discord.EphemeralMessage,
+	}
+}
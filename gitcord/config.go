@@ -1,9 +1,15 @@
 package gitcord
 
 import (
+	"fmt"
 	"log"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/ethanthatonekid/gitcord/gitcord/internal/slices"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
 	"golang.org/x/oauth2"
 )
 
@@ -11,20 +17,275 @@ import (
 type Config struct {
 	// GitHubOAuth is the GitHub OAuth token
 	GitHubOAuth oauth2.TokenSource
+	// GitHubApp authenticates as a GitHub App installation instead of with
+	// GitHubOAuth. Refer to GitHubAppConfig for more information.
+	GitHubApp *GitHubAppConfig
+	// GitHubRepo is the owner/repo repository that DoEventID fetches events
+	// from. Events handled by DoEvent carry their own repository.
+	GitHubRepo string
 	// DiscordToken is the Discord bot token
 	DiscordToken string
 	// DiscordChannelID is the ID of the parent channel in which all threads
-	// will be created under
+	// will be created under. It may be a text channel or a forum channel, in
+	// which case forum posts are created instead.
 	DiscordChannelID discord.ChannelID
+	// Routes routes the events of some repositories into other channels than
+	// DiscordChannelID. Refer to Routes for more information.
+	Routes Routes
+	// Rules drop events, route them into other channels or mention roles in
+	// their messages. Refer to Rule for more information.
+	Rules Rules
+	// Channels are the channels that events not belonging to an issue or
+	// pull request thread are posted into. Refer to FeedChannels for more
+	// information.
+	Channels FeedChannels
+	// Refs filters the branches and tags whose creation and deletion are
+	// posted into Channels.Refs. Refer to RefFilter for more information.
+	Refs RefFilter
+	// CreateForumTags will create forum tags for labels that do not have a
+	// matching tag yet. Forum tags are only used if DiscordChannelID is a
+	// forum channel.
+	CreateForumTags bool
 	// ColorScheme is the color scheme for use in embeds. Refer to ColorScheme
 	// for more information.
 	ColorScheme ColorScheme
+	// Store maps issues and pull requests to their Discord threads, and
+	// comments, reviews and review threads to their Discord messages. If nil,
+	// an in-memory store is used, and threads and messages created by previous
+	// runs are found by scanning thread names and embed footers.
+	Store store.Store
+	// CommandPermissions maps Discord slash command actions to the roles
+	// allowed to take them. Slash commands are only registered by Gateway if
+	// any permissions are given.
+	CommandPermissions CommandPermissions
 	// ForceOpen will force create a new thread even if one already exists
 	ForceOpen bool
+	// DeferDraftThreads holds off opening the thread of a draft pull request
+	// until it is ready for review. Events of draft pull requests without a
+	// thread are dropped until then.
+	DeferDraftThreads bool
 	// Logger is the logger to use. If nil, the default logger will be used
 	Logger *log.Logger
 }
 
+// FeedChannels are the text channels that events not belonging to an issue or
+// pull request thread are posted into. Such events are dropped if their
+// channel is not set, unless a rule routes them elsewhere.
+type FeedChannels struct {
+	// Pushes receives pushes to branches without an open pull request.
+	// Pushes to the branch of an open pull request with a thread are posted
+	// into that thread by its synchronize event instead.
+	Pushes discord.ChannelID
+	// Releases receives release announcements.
+	Releases discord.ChannelID
+	// CrosspostReleases publishes release announcements to the servers
+	// following Releases, if it is an announcement channel.
+	CrosspostReleases bool
+	// Deployments receives deployments, deployment statuses and commit
+	// statuses of commits that are not the head of an open pull request.
+	// Those of pull request heads are posted into the pull request's thread
+	// instead.
+	Deployments discord.ChannelID
+	// Refs receives the creation and deletion of branches and tags matching
+	// Config.Refs.
+	Refs discord.ChannelID
+	// Security receives Dependabot, code scanning and secret scanning alerts
+	// and security advisories. It should only be visible to maintainers.
+	Security discord.ChannelID
+	// Activity receives stars, forks, collaborator changes, repositories
+	// made public and sponsorships.
+	Activity discord.ChannelID
+	// ActivityBatch, if positive, batches stars and forks: those of a
+	// repository within ActivityBatch of the first one are edited into its
+	// message rather than sent as new messages.
+	ActivityBatch time.Duration
+	// Milestones receives created, closed, reopened, edited and deleted
+	// milestones.
+	Milestones discord.ChannelID
+	// Labels receives created, edited and deleted labels.
+	Labels discord.ChannelID
+	// Commits receives comments on commits that are not in an open pull
+	// request. Those in one are posted into the pull request's thread
+	// instead.
+	Commits discord.ChannelID
+	// Wiki receives the pages changed by pushes to the wiki.
+	Wiki discord.ChannelID
+	// WikiDiffs sends the diff of each changed page along with them, which
+	// are fetched from the wiki's Git repository and require git.
+	WikiDiffs bool
+}
+
+// RefFilter filters branches and tags by their names. Either list may be empty
+// to allow every branch or tag respectively.
+type RefFilter struct {
+	// Branches are branch name patterns in the syntax of path.Match, e.g.
+	// "release/*".
+	Branches []string
+	// Tags are tag name patterns in the syntax of path.Match, e.g. "v*".
+	Tags []string
+}
+
+// Matches returns whether the ref of the given type, "branch" or "tag", passes
+// the filter. Other ref types never pass.
+func (f RefFilter) Matches(refType, ref string) bool {
+	var patterns []string
+	switch refType {
+	case "branch":
+		patterns = f.Branches
+	case "tag":
+		patterns = f.Tags
+	default:
+		return false
+	}
+
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, ref); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Route routes the events of the repositories matching Repo into the parent
+// channel ChannelID.
+type Route struct {
+	// Repo is an owner/repo pattern in the syntax of path.Match, e.g.
+	// "ethanthatonekid/gitcord" or "ethanthatonekid/*".
+	Repo      string
+	ChannelID discord.ChannelID
+}
+
+// Routes is a routing table of repositories to Discord channels. The first
+// route that matches a repository wins.
+type Routes []Route
+
+// ChannelFor returns the channel of the first route matching the owner/repo
+// repository.
+func (r Routes) ChannelFor(repo string) (discord.ChannelID, bool) {
+	for _, route := range r {
+		if ok, _ := path.Match(route.Repo, repo); ok {
+			return route.ChannelID, true
+		}
+	}
+	return 0, false
+}
+
+// channelFor returns the parent channel of the owner/repo repository, which
+// is DiscordChannelID unless a route matches.
+func (c *Config) channelFor(repo string) discord.ChannelID {
+	if ch, ok := c.Routes.ChannelFor(repo); ok {
+		return ch
+	}
+	return c.DiscordChannelID
+}
+
+// sharedChannel returns true if the parent channel ch may receive the events
+// of more than one repository: it is the fallback of a routing table, is the
+// target of a rule, a glob route or of several routes.
+func (c *Config) sharedChannel(ch discord.ChannelID) bool {
+	if len(c.Routes) == 0 {
+		return false
+	}
+
+	if ch == c.DiscordChannelID {
+		return true
+	}
+
+	for _, rule := range c.Rules {
+		if rule.ChannelID == ch {
+			return true
+		}
+	}
+
+	var n int
+	for _, route := range c.Routes {
+		if route.ChannelID != ch {
+			continue
+		}
+		if strings.ContainsAny(route.Repo, `*?[\`) {
+			return true
+		}
+		n++
+	}
+
+	return n > 1
+}
+
+// channelIDs returns the IDs of every parent channel.
+func (c *Config) channelIDs() []discord.ChannelID {
+	all := []discord.ChannelID{c.DiscordChannelID}
+	for _, route := range c.Routes {
+		all = append(all, route.ChannelID)
+	}
+	for _, rule := range c.Rules {
+		all = append(all, rule.ChannelID)
+	}
+
+	var ids []discord.ChannelID
+	for _, id := range all {
+		if id.IsValid() && slices.Find(ids, func(seen *discord.ChannelID) bool { return *seen == id }) == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// GitHubAppConfig is the configuration for authenticating as a GitHub App.
+// Short-lived installation tokens are minted from the app's private key and
+// renewed as they expire.
+type GitHubAppConfig struct {
+	// AppID is the ID of the GitHub App.
+	AppID int64
+	// PrivateKey is the PEM-encoded private key of the GitHub App.
+	PrivateKey []byte
+	// InstallationID is the ID of the app's installation on the account
+	// owning the repositories. If zero, the installation is discovered per
+	// event from its payload or repository, which lets a single server
+	// handle every installation of the app.
+	InstallationID int64
+}
+
+// CommandAction is an action on an issue or pull request that may be taken
+// through a Discord slash command.
+type CommandAction string
+
+const (
+	CloseAction         CommandAction = "close"
+	ReopenAction        CommandAction = "reopen"
+	LabelAction         CommandAction = "label"
+	AssignAction        CommandAction = "assign"
+	RequestReviewAction CommandAction = "request-review"
+	LockAction          CommandAction = "lock"
+
+	// AnyAction grants roles every action in CommandPermissions.
+	AnyAction CommandAction = "*"
+)
+
+// CommandPermissions maps slash command actions to the IDs of the Discord
+// roles whose members may take them. Actions without any roles may not be
+// taken by anyone.
+type CommandPermissions map[CommandAction][]discord.RoleID
+
+// Allows returns true if a member with the given roles may take the action.
+func (p CommandPermissions) Allows(action CommandAction, roles []discord.RoleID) bool {
+	for _, allowed := range [][]discord.RoleID{p[action], p[AnyAction]} {
+		for _, a := range allowed {
+			for _, r := range roles {
+				if a == r {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 type StatusColors struct {
 	Success discord.Color
 	Error   discord.Color
@@ -79,15 +340,161 @@ const (
 	ReviewThreaded
 	ReviewThreadResolved
 	ReviewThreadUnresolved
+	Pushed           // Error is used for force pushes
+	ReleasePublished // Error is used for pre-releases
+	ReleaseDeleted
+	DiscussionOpened
+	DiscussionAnswered // Error is used when an answer is unmarked
+	DiscussionDeleted
+	DiscussionCommented
+	DiscussionCommentDeleted
+	CIStatus // Error is used if any check failed
+	CIPending
+	CIFailed
+	CommitStatus // Error is used for failures and errors
+	DeploymentCreated
+	DeploymentStatusChanged // Error is used for failures and errors
+	RefCreated
+	RefDeleted
+	SecurityCritical // only Success is used by the security severity keys
+	SecurityHigh
+	SecurityMedium
+	SecurityLow
+	SecurityResolved
+	Starred
+	Forked
+	MemberChanged // Error is used for removed collaborators
+	RepoPublicized
+	Sponsored // Error is used for cancelled sponsorships
+	MilestoneCreated
+	MilestoneClosed
+	MilestoneReopened
+	MilestoneEdited
+	MilestoneDeleted
+	LabelChanged // only used for labels without a valid color
+	CommitCommented
+	WikiChanged
+	ProjectItemMoved
+	PRAutoMerge       // Error is used when auto-merge is disabled
+	PRMergeQueued     // Error is used when removed from the merge queue
+	MergeGroupChanged // Error is used for merge groups destroyed unmerged
+	PRDraft           // used instead of issue_opened for draft pull requests
+	PRConvertedToDraft
+	PRSynchronized // Error is used for force pushes
 
 	maxColorSchemeKey // internal use only
 )
 
+// colorSchemeKeyNames are the names of color scheme keys as used in
+// configuration.
+var colorSchemeKeyNames = [maxColorSchemeKey]string{
+	UnknownColorSchemeKey:    "unknown",
+	IssueOpened:              "issue_opened",
+	IssueClosed:              "issue_closed",
+	IssueReopened:            "issue_reopened",
+	IssueLabeled:             "issue_labeled",
+	IssueUnlabeled:           "issue_unlabeled",
+	IssueAssigned:            "issue_assigned",
+	IssueUnassigned:          "issue_unassigned",
+	IssueMilestoned:          "issue_milestoned",
+	IssueDemilestoned:        "issue_demilestoned",
+	IssueDeleted:             "issue_deleted",
+	IssueLocked:              "issue_locked",
+	IssueUnlocked:            "issue_unlocked",
+	IssueTransferred:         "issue_transferred",
+	IssueCommented:           "issue_commented",
+	IssueCommentDeleted:      "issue_comment_deleted",
+	PROpened:                 "pr_opened",
+	PRReopened:               "pr_reopened",
+	PRCommented:              "pr_commented",
+	PRClosed:                 "pr_closed",
+	PRAssigned:               "pr_assigned",
+	PRUnassigned:             "pr_unassigned",
+	PRDeleted:                "pr_deleted",
+	PRTransferred:            "pr_transferred",
+	PRLabeled:                "pr_labeled",
+	PRUnlabeled:              "pr_unlabeled",
+	PRMilestoned:             "pr_milestoned",
+	PRDemilestoned:           "pr_demilestoned",
+	PRLocked:                 "pr_locked",
+	PRUnlocked:               "pr_unlocked",
+	PRReviewRequested:        "pr_review_requested",
+	PRReviewRequestRemoved:   "pr_review_request_removed",
+	PRReadyForReview:         "pr_ready_for_review",
+	Reviewed:                 "reviewed",
+	ReviewDismissed:          "review_dismissed",
+	ReviewCommented:          "review_commented",
+	ReviewCommentDeleted:     "review_comment_deleted",
+	ReviewThreaded:           "review_threaded",
+	ReviewThreadResolved:     "review_thread_resolved",
+	ReviewThreadUnresolved:   "review_thread_unresolved",
+	Pushed:                   "pushed",
+	ReleasePublished:         "release_published",
+	ReleaseDeleted:           "release_deleted",
+	DiscussionOpened:         "discussion_opened",
+	DiscussionAnswered:       "discussion_answered",
+	DiscussionDeleted:        "discussion_deleted",
+	DiscussionCommented:      "discussion_commented",
+	DiscussionCommentDeleted: "discussion_comment_deleted",
+	CIStatus:                 "ci_status",
+	CIPending:                "ci_pending",
+	CIFailed:                 "ci_failed",
+	CommitStatus:             "commit_status",
+	DeploymentCreated:        "deployment_created",
+	DeploymentStatusChanged:  "deployment_status_changed",
+	RefCreated:               "ref_created",
+	RefDeleted:               "ref_deleted",
+	SecurityCritical:         "security_critical",
+	SecurityHigh:             "security_high",
+	SecurityMedium:           "security_medium",
+	SecurityLow:              "security_low",
+	SecurityResolved:         "security_resolved",
+	Starred:                  "starred",
+	Forked:                   "forked",
+	MemberChanged:            "member_changed",
+	RepoPublicized:           "repo_publicized",
+	Sponsored:                "sponsored",
+	MilestoneCreated:         "milestone_created",
+	MilestoneClosed:          "milestone_closed",
+	MilestoneReopened:        "milestone_reopened",
+	MilestoneEdited:          "milestone_edited",
+	MilestoneDeleted:         "milestone_deleted",
+	LabelChanged:             "label_changed",
+	CommitCommented:          "commit_commented",
+	WikiChanged:              "wiki_changed",
+	ProjectItemMoved:         "project_item_moved",
+	PRAutoMerge:              "pr_auto_merge",
+	PRMergeQueued:            "pr_merge_queued",
+	MergeGroupChanged:        "merge_group_changed",
+	PRDraft:                  "pr_draft",
+	PRConvertedToDraft:       "pr_converted_to_draft",
+	PRSynchronized:           "pr_synchronized",
+}
+
+// String returns the snake_case name of the key, e.g. "issue_opened".
+func (k ColorSchemeKey) String() string {
+	if k < maxColorSchemeKey && colorSchemeKeyNames[k] != "" {
+		return colorSchemeKeyNames[k]
+	}
+	return fmt.Sprintf("ColorSchemeKey(%d)", uint(k))
+}
+
+// ParseColorSchemeKey parses the snake_case name of a color scheme key.
+func ParseColorSchemeKey(name string) (ColorSchemeKey, bool) {
+	for k, n := range colorSchemeKeyNames {
+		if n == name && ColorSchemeKey(k) != UnknownColorSchemeKey {
+			return ColorSchemeKey(k), true
+		}
+	}
+	return UnknownColorSchemeKey, false
+}
+
 // ColorScheme describes the color scheme for all embed colors made by gitcord.
 // It maps each color scheme key to a status color struct, which has two
 // possible colors for two cases.
 //
-// By default, all color scheme keys ap to DefaultStatusColors.
+// Keys missing from a color scheme use their colors in DefaultColorScheme,
+// which maps most keys to DefaultStatusColors.
 type ColorScheme map[ColorSchemeKey]StatusColors
 
 // DefaultColorScheme is the default color scheme.
@@ -97,6 +504,15 @@ func init() {
 	for i := 0; i < int(maxColorSchemeKey); i++ {
 		DefaultColorScheme[ColorSchemeKey(i)] = DefaultStatusColors
 	}
+
+	// Security alerts are colored by severity rather than by status.
+	DefaultColorScheme[SecurityCritical] = StatusColors{Success: 0x8B0000, Error: 0x8B0000}
+	DefaultColorScheme[SecurityHigh] = StatusColors{Success: 0xFF0000, Error: 0xFF0000}
+	DefaultColorScheme[SecurityMedium] = StatusColors{Success: 0xFF8C00, Error: 0xFF8C00}
+	DefaultColorScheme[SecurityLow] = StatusColors{Success: 0xFFD700, Error: 0xFFD700}
+
+	// Drafts are gray like on GitHub.
+	DefaultColorScheme[PRDraft] = StatusColors{Success: 0x6E7681, Error: 0x6E7681}
 }
 
 // Override creates a new ColorScheme that overrides all color keys inside s
@@ -118,6 +534,9 @@ func (s ColorScheme) Override(with ColorScheme) ColorScheme {
 // the Success color is used, else Error is used.
 func (s ColorScheme) Color(k ColorSchemeKey, success bool) discord.Color {
 	colors, ok := s[k]
+	if !ok {
+		colors, ok = DefaultColorScheme[k]
+	}
 	if !ok {
 		colors = DefaultStatusColors
 	}
@@ -0,0 +1,157 @@
+package gitcord
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestCommandPermissions(t *testing.T) {
+	perms := CommandPermissions{
+		AnyAction:   {1},
+		LabelAction: {2, 3},
+	}
+
+	type test struct {
+		action CommandAction
+		roles  []discord.RoleID
+		allows bool
+	}
+
+	tests := []test{
+		{action: CloseAction, roles: []discord.RoleID{1}, allows: true},
+		{action: LabelAction, roles: []discord.RoleID{4, 3}, allows: true},
+		{action: CloseAction, roles: []discord.RoleID{2}, allows: false},
+		{action: LockAction, roles: nil, allows: false},
+	}
+
+	for _, test := range tests {
+		if got := perms.Allows(test.action, test.roles); got != test.allows {
+			t.Errorf("Allows(%q, %v) = %v, want %v", test.action, test.roles, got, test.allows)
+		}
+	}
+
+	if (CommandPermissions)(nil).Allows(CloseAction, []discord.RoleID{1}) {
+		t.Error("nil permissions must not allow anything")
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	cfg := Config{
+		DiscordChannelID: 1,
+		Routes: Routes{
+			{Repo: "ethanthatonekid/gitcord", ChannelID: 2},
+			{Repo: "ethanthatonekid/*", ChannelID: 3},
+			{Repo: "acmecorp/api", ChannelID: 4},
+			{Repo: "acmecorp/web", ChannelID: 4},
+		},
+	}
+
+	type test struct {
+		repo    string
+		channel discord.ChannelID
+		shared  bool
+	}
+
+	tests := []test{
+		{repo: "ethanthatonekid/gitcord", channel: 2, shared: false},
+		{repo: "ethanthatonekid/acmcsuf.com", channel: 3, shared: true},
+		{repo: "acmecorp/api", channel: 4, shared: true},
+		{repo: "someone/else", channel: 1, shared: true},
+	}
+
+	for _, test := range tests {
+		ch := cfg.channelFor(test.repo)
+		if ch != test.channel {
+			t.Errorf("channelFor(%q) = %d, want %d", test.repo, ch, test.channel)
+		}
+		if shared := cfg.sharedChannel(ch); shared != test.shared {
+			t.Errorf("sharedChannel(%d) = %v, want %v", ch, shared, test.shared)
+		}
+	}
+
+	single := Config{DiscordChannelID: 1}
+	if single.sharedChannel(1) {
+		t.Error("channel without routes must not be shared")
+	}
+}
+
+func TestRefFilter(t *testing.T) {
+	filter := RefFilter{
+		Branches: []string{"main", "release/*"},
+		Tags:     []string{"v*"},
+	}
+
+	type test struct {
+		refType string
+		ref     string
+		match   bool
+	}
+
+	tests := []test{
+		{"branch", "main", true},
+		{"branch", "release/1.0", true},
+		{"branch", "feature/x", false},
+		{"tag", "v1.0.0", true},
+		{"tag", "nightly", false},
+		{"repository", "", false},
+	}
+
+	for _, test := range tests {
+		if match := filter.Matches(test.refType, test.ref); match != test.match {
+			t.Errorf("Matches(%q, %q) = %v, want %v", test.refType, test.ref, match, test.match)
+		}
+	}
+
+	if !(RefFilter{}).Matches("tag", "nightly") {
+		t.Error("empty filter must match every tag")
+	}
+}
+
+func TestColorSchemeKeyNames(t *testing.T) {
+	for k := UnknownColorSchemeKey + 1; k < maxColorSchemeKey; k++ {
+		name := colorSchemeKeyNames[k]
+		if name == "" {
+			t.Errorf("color scheme key %d has no name", k)
+			continue
+		}
+
+		parsed, ok := ParseColorSchemeKey(name)
+		if !ok || parsed != k {
+			t.Errorf("ParseColorSchemeKey(%q) = %d, %v, want %d", name, parsed, ok, k)
+		}
+	}
+}
+
+func TestColorSchemeDefaults(t *testing.T) {
+	// Configurations only hold the colors overridden by the user.
+	var cfg Config
+
+	type test struct {
+		severity string
+		resolved bool
+		color    discord.Color
+	}
+
+	tests := []test{
+		{severity: "critical", color: 0x8B0000},
+		{severity: "high", color: 0xFF0000},
+		{severity: "moderate", color: 0xFF8C00},
+		{severity: "low", color: 0xFFD700},
+		{severity: "critical", resolved: true, color: DefaultStatusColors.Success},
+	}
+
+	for _, test := range tests {
+		if color := cfg.securityColor(test.severity, test.resolved); color != test.color {
+			t.Errorf("securityColor(%q, %v) = %06X, want %06X", test.severity, test.resolved, color, test.color)
+		}
+	}
+
+	cfg.ColorScheme = ColorScheme{SecurityHigh: {Success: 0x123456}}
+	if color := cfg.securityColor("high", false); color != 0x123456 {
+		t.Errorf("overridden color is %06X", color)
+	}
+	if color := cfg.securityColor("low", false); color != 0xFFD700 {
+		t.Errorf("color next to an overridden one is %06X", color)
+	}
+}
@@ -2,11 +2,15 @@ package gitcord
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/ethanthatonekid/gitcord/gitcord/internal/discordclient"
 	"github.com/ethanthatonekid/gitcord/gitcord/internal/markdown"
+	"github.com/ethanthatonekid/gitcord/gitcord/internal/slices"
 	"github.com/google/go-github/v47/github"
 )
 
@@ -269,6 +273,15 @@ func (c *Config) makePREmbed(ev *github.PullRequestEvent) discord.Embed {
 
 	var fields []discord.EmbedField
 
+	color := c.ColorScheme.Color(IssueOpened, true)
+	if pr.GetDraft() {
+		color = c.ColorScheme.Color(PRDraft, true)
+		fields = append(fields, discord.EmbedField{
+			Name:  "Draft",
+			Value: "📝 This pull request is still a draft",
+		})
+	}
+
 	if len(pr.Labels) > 0 {
 		fields = append(fields, discord.EmbedField{
 			Name:  "Labels",
@@ -305,6 +318,13 @@ func (c *Config) makePREmbed(ev *github.PullRequestEvent) discord.Embed {
 		})
 	}
 
+	if pr.GetCommits() > 0 {
+		fields = append(fields, discord.EmbedField{
+			Name:  "Changes",
+			Value: makePRStats(pr),
+		})
+	}
+
 	return discord.Embed{
 		Title: discordclient.PRMsgPrefix + fmt.Sprintf("%d %s", pr.GetNumber(), pr.GetTitle()),
 		URL:   pr.GetHTMLURL(),
@@ -314,7 +334,7 @@ func (c *Config) makePREmbed(ev *github.PullRequestEvent) discord.Embed {
 			Icon: pr.GetUser().GetAvatarURL(),
 		},
 		Description: markdown.Convert(pr.GetBody(), pr.GetHTMLURL()),
-		Color:       c.ColorScheme.Color(IssueOpened, true),
+		Color:       color,
 		Fields:      fields,
 	}
 }
@@ -514,6 +534,220 @@ func (c *Config) makePRReadyForReviewEmbed(ev *github.PullRequestEvent) discord.
 	}
 }
 
+func (c *Config) makePRConvertedToDraftEmbed(ev *github.PullRequestEvent) discord.Embed {
+	return discord.Embed{
+		Title: fmt.Sprintf("Pull request #%d converted to draft", ev.GetPullRequest().GetNumber()),
+		Color: c.ColorScheme.Color(PRConvertedToDraft, true),
+		URL:   ev.GetPullRequest().GetHTMLURL(),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makePRAutoMergeEmbed(ev *PullRequestEvent) discord.Embed {
+	pr := ev.GetPullRequest()
+	enabled := ev.GetAction() == "auto_merge_enabled"
+
+	embed := discord.Embed{
+		Title: fmt.Sprintf("Auto-merge disabled for pull request #%d", pr.GetNumber()),
+		Color: c.ColorScheme.Color(PRAutoMerge, enabled),
+		URL:   pr.GetHTMLURL(),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+
+	if enabled {
+		embed.Title = fmt.Sprintf("Auto-merge enabled for pull request #%d", pr.GetNumber())
+		if method := pr.GetAutoMerge().GetMergeMethod(); method != "" {
+			embed.Fields = append(embed.Fields, discord.EmbedField{
+				Name:  "Merge method",
+				Value: method,
+			})
+		}
+	}
+
+	if reason := ev.GetReason(); reason != "" {
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:  "Reason",
+			Value: formatReason(reason),
+		})
+	}
+
+	return embed
+}
+
+func (c *Config) makePRMergeQueueEmbed(ev *PullRequestEvent) discord.Embed {
+	pr := ev.GetPullRequest()
+	enqueued := ev.GetAction() == "enqueued"
+
+	embed := discord.Embed{
+		Title: fmt.Sprintf("Pull request #%d removed from the merge queue", pr.GetNumber()),
+		Color: c.ColorScheme.Color(PRMergeQueued, enqueued),
+		URL:   pr.GetHTMLURL(),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+
+	if enqueued {
+		embed.Title = fmt.Sprintf("Pull request #%d added to the merge queue", pr.GetNumber())
+		embed.URL = ev.GetRepo().GetHTMLURL() + "/queue/" + pr.GetBase().GetRef()
+	}
+
+	if reason := ev.GetReason(); reason != "" {
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:  "Reason",
+			Value: formatReason(reason),
+		})
+	}
+
+	return embed
+}
+
+func (c *Config) makeMergeGroupEmbed(ev *MergeGroupEvent, number int) discord.Embed {
+	group := ev.GetMergeGroup()
+	sha := group.GetHeadSHA()
+
+	var title string
+	switch ev.GetAction() {
+	case "checks_requested":
+		title = fmt.Sprintf("Merge queue is checking pull request #%d", number)
+	case "destroyed":
+		title = fmt.Sprintf("Merge group of pull request #%d %s", number, ev.GetReason())
+	default:
+		title = fmt.Sprintf("Merge group of pull request #%d %s", number, formatAction(ev.GetAction()))
+	}
+
+	success := ev.GetAction() != "destroyed" || ev.GetReason() == "merged"
+
+	return discord.Embed{
+		Title: title,
+		URL:   ev.GetRepo().GetHTMLURL() + "/commit/" + sha + "/checks",
+		Color: c.ColorScheme.Color(MergeGroupChanged, success),
+		Fields: []discord.EmbedField{
+			{
+				Name:   "Commit",
+				Value:  markdown.ConvertHyperlink("`"+shortSHA(sha)+"`", ev.GetRepo().GetHTMLURL()+"/commit/"+sha),
+				Inline: true,
+			},
+			{
+				Name:   "Base",
+				Value:  "`" + strings.TrimPrefix(group.GetBaseRef(), "refs/heads/") + "`",
+				Inline: true,
+			},
+		},
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+// formatReason formats a reason like "MERGE_CONFLICT" as "merge conflict".
+func formatReason(reason string) string {
+	return strings.ToLower(formatAction(reason))
+}
+
+// makePRSynchronizedEmbed makes the embed for new commits pushed to a pull
+// request. comparison holds the commits between the previous and the new head;
+// it is nil if they could not be compared.
+func (c *Config) makePRSynchronizedEmbed(ev *github.PullRequestEvent, comparison *github.CommitsComparison) discord.Embed {
+	pr := ev.GetPullRequest()
+
+	// The previous head is not an ancestor of the new head after a force
+	// push, so the comparison is not simply ahead.
+	forced := comparison != nil && comparison.GetStatus() != "ahead"
+
+	// Only the first page of commits is fetched, but the total is known.
+	var commits []*github.RepositoryCommit
+	var total int
+	if comparison != nil && !forced {
+		commits = comparison.Commits
+		total = comparison.GetTotalCommits()
+		if total < len(commits) {
+			total = len(commits)
+		}
+	}
+
+	var title string
+	switch n := total; {
+	case forced:
+		title = fmt.Sprintf("Pull request #%d force-pushed", pr.GetNumber())
+	case n == 1:
+		title = fmt.Sprintf("Pull request #%d: 1 new commit", pr.GetNumber())
+	case n > 1:
+		title = fmt.Sprintf("Pull request #%d: %d new commits", pr.GetNumber(), n)
+	default:
+		title = fmt.Sprintf("Pull request #%d updated", pr.GetNumber())
+	}
+
+	lines := make([]string, len(commits))
+	for i, commit := range commits {
+		author := commit.GetAuthor().GetLogin()
+		if author == "" {
+			author = commit.GetCommit().GetAuthor().GetName()
+		}
+
+		lines[i] = commitLine(commit.GetSHA(), commit.GetHTMLURL(), commit.GetCommit().GetMessage(), author)
+	}
+
+	url := pr.GetHTMLURL() + "/files"
+	if len(commits) > 0 {
+		url = pr.GetHTMLURL() + "/files/" + ev.GetBefore() + ".." + ev.GetAfter()
+	}
+
+	return discord.Embed{
+		Title:       title,
+		URL:         url,
+		Description: formatCommitLines(lines, total),
+		Color:       c.ColorScheme.Color(PRSynchronized, !forced),
+		Fields: []discord.EmbedField{
+			{
+				Name:   "Head",
+				Value:  markdown.ConvertHyperlink("`"+shortSHA(ev.GetAfter())+"`", pr.GetHTMLURL()+"/commits/"+ev.GetAfter()),
+				Inline: true,
+			},
+			{
+				Name:   "Changes",
+				Value:  makePRStats(pr),
+				Inline: true,
+			},
+		},
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+// makePRStats summarizes the diff stats of a pull request.
+func makePRStats(pr *github.PullRequest) string {
+	return fmt.Sprintf("%s, +%d −%d in %s",
+		plural(pr.GetCommits(), "commit"),
+		pr.GetAdditions(),
+		pr.GetDeletions(),
+		plural(pr.GetChangedFiles(), "file"),
+	)
+}
+
+// plural formats n followed by noun, pluralized if n is not 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
 /// END PullRequestEvent Discord embeds
 /// START PullRequestReviewEvent Discord embeds
 
@@ -624,6 +858,8 @@ func (c *Config) makePRReviewThreadEmbed(ev *github.PullRequestReviewThreadEvent
 			Name: ev.GetSender().GetLogin(),
 			Icon: ev.GetSender().GetAvatarURL(),
 		},
+		// Footer is used to store the thread ID, similar to makeIssueCommentEmbed
+		Footer: &discord.EmbedFooter{Text: strconv.FormatInt(t.GetID(), 10)},
 	}
 }
 
@@ -659,6 +895,1414 @@ func (c *Config) makePRReviewThreadUnresolvedEmbed(ev *github.PullRequestReviewT
 
 /// END PullRequestReviewThreadEvent Discord embeds
 
+/// START PushEvent Discord embeds
+
+// maxPushCommits is the maximum number of commits listed in a push embed.
+const maxPushCommits = 10
+
+func (c *Config) makePushEmbed(ev *github.PushEvent) discord.Embed {
+	branch := strings.TrimPrefix(ev.GetRef(), "refs/heads/")
+
+	var title string
+	switch n := len(ev.Commits); {
+	case ev.GetForced():
+		title = fmt.Sprintf("[%s:%s] force-pushed", ev.GetRepo().GetName(), branch)
+	case ev.GetCreated():
+		title = fmt.Sprintf("[%s:%s] new branch pushed", ev.GetRepo().GetName(), branch)
+	case n == 1:
+		title = fmt.Sprintf("[%s:%s] 1 new commit", ev.GetRepo().GetName(), branch)
+	default:
+		title = fmt.Sprintf("[%s:%s] %d new commits", ev.GetRepo().GetName(), branch, n)
+	}
+
+	lines := make([]string, len(ev.Commits))
+	for i, commit := range ev.Commits {
+		lines[i] = commitLine(commitSHA(commit), commit.GetURL(), commit.GetMessage(), commitAuthorName(commit.GetAuthor()))
+	}
+
+	fields := []discord.EmbedField{
+		{
+			Name:   "Branch",
+			Value:  markdown.ConvertHyperlink(branch, ev.GetRepo().GetHTMLURL()+"/tree/"+branch),
+			Inline: true,
+		},
+	}
+
+	if ev.GetForced() {
+		fields = append(fields, discord.EmbedField{
+			Name:   "Force-pushed",
+			Value:  fmt.Sprintf("`%s` → `%s`", shortSHA(ev.GetBefore()), shortSHA(ev.GetAfter())),
+			Inline: true,
+		})
+	}
+
+	return discord.Embed{
+		Title:       title,
+		URL:         ev.GetCompare(),
+		Description: formatCommitLines(lines, len(ev.Commits)),
+		Color:       c.ColorScheme.Color(Pushed, !ev.GetForced()),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+/// END PushEvent Discord embeds
+/// START ReleaseEvent Discord embeds
+
+// maxReleaseAssets is the maximum number of assets listed in a release embed.
+const maxReleaseAssets = 10
+
+// maxFieldLen is the maximum length of the value of an embed field. Discord
+// rejects messages with longer ones.
+const maxFieldLen = 1024
+
+func (c *Config) makeReleaseEmbed(ev *github.ReleaseEvent) discord.Embed {
+	release := ev.GetRelease()
+
+	name := release.GetName()
+	if name == "" {
+		name = release.GetTagName()
+	}
+
+	kind := "Release"
+	if release.GetPrerelease() {
+		kind = "Pre-release"
+	}
+
+	fields := []discord.EmbedField{
+		{
+			Name:   "Tag",
+			Value:  markdown.ConvertHyperlink(release.GetTagName(), ev.GetRepo().GetHTMLURL()+"/tree/"+release.GetTagName()),
+			Inline: true,
+		},
+	}
+
+	if len(release.Assets) > 0 {
+		fields = append(fields, discord.EmbedField{
+			Name:  "Assets",
+			Value: formatReleaseAssets(release.Assets),
+		})
+	}
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("[%s] %s %s", ev.GetRepo().GetName(), kind, name),
+		URL:         release.GetHTMLURL(),
+		Description: markdown.Convert(release.GetBody(), release.GetHTMLURL()),
+		Color:       c.ColorScheme.Color(ReleasePublished, !release.GetPrerelease()),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  release.GetAuthor().GetHTMLURL(),
+			Name: release.GetAuthor().GetLogin(),
+			Icon: release.GetAuthor().GetAvatarURL(),
+		},
+	}
+}
+
+// formatReleaseAssets lists the first assets with their size and download
+// count, as many as fit within an embed field.
+func formatReleaseAssets(assets []*github.ReleaseAsset) string {
+	// Leave room for the last line counting the assets left out.
+	const maxLen = maxFieldLen - len("\n… and 1000 more")
+
+	var value strings.Builder
+	var shown int
+	for _, asset := range assets {
+		if shown == maxReleaseAssets {
+			break
+		}
+
+		line := fmt.Sprintf("%s (%s, %s)\n",
+			markdown.ConvertHyperlink(asset.GetName(), asset.GetBrowserDownloadURL()),
+			formatSize(asset.GetSize()),
+			plural(asset.GetDownloadCount(), "download"),
+		)
+		if value.Len()+len(line) > maxLen {
+			break
+		}
+
+		value.WriteString(line)
+		shown++
+	}
+
+	if shown < len(assets) {
+		fmt.Fprintf(&value, "… and %d more", len(assets)-shown)
+	}
+
+	return strings.TrimSpace(value.String())
+}
+
+func (c *Config) makeReleaseDeletedEmbed(ev *github.ReleaseEvent) discord.Embed {
+	release := ev.GetRelease()
+
+	name := release.GetName()
+	if name == "" {
+		name = release.GetTagName()
+	}
+
+	return discord.Embed{
+		Title: fmt.Sprintf("[%s] Release %s deleted", ev.GetRepo().GetName(), name),
+		Color: c.ColorScheme.Color(ReleaseDeleted, true),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+// formatSize formats a size in bytes in binary units, e.g. "1.5 MiB".
+func formatSize(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := unit, 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+/// END ReleaseEvent Discord embeds
+/// START DiscussionEvent Discord embeds
+
+func (c *Config) makeDiscussionEmbed(d *Discussion) discord.Embed {
+	fields := []discord.EmbedField{
+		{
+			Name:   "Category",
+			Value:  d.GetCategory().GetName(),
+			Inline: true,
+		},
+	}
+
+	if d.GetCategory().GetIsAnswerable() {
+		answered := "no"
+		if url := d.GetAnswerHTMLURL(); url != "" {
+			answered = "✅ " + markdown.ConvertHyperlink("yes", url)
+		}
+		fields = append(fields, discord.EmbedField{
+			Name:   "Answered",
+			Value:  answered,
+			Inline: true,
+		})
+	}
+
+	if len(d.Labels) > 0 {
+		fields = append(fields, discord.EmbedField{
+			Name:  "Labels",
+			Value: markdown.ConvertLabels(d.Labels),
+		})
+	}
+
+	if d.GetLocked() {
+		fields = append(fields, discord.EmbedField{
+			Name:  "Locked",
+			Value: "🔒",
+		})
+	}
+
+	return discord.Embed{
+		Title: discordclient.DiscussionMsgPrefix + fmt.Sprintf("%d %s", d.GetNumber(), d.GetTitle()),
+		URL:   d.GetHTMLURL(),
+		Author: &discord.EmbedAuthor{
+			URL:  d.GetUser().GetHTMLURL(),
+			Name: d.GetUser().GetLogin(),
+			Icon: d.GetUser().GetAvatarURL(),
+		},
+		Description: markdown.Convert(d.GetBody(), d.GetHTMLURL()),
+		Color:       c.ColorScheme.Color(DiscussionOpened, true),
+		Fields:      fields,
+	}
+}
+
+func (c *Config) makeDiscussionAnsweredEmbed(ev *DiscussionEvent) discord.Embed {
+	answer := ev.GetAnswer()
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("Answer chosen for discussion #%d", ev.GetDiscussion().GetNumber()),
+		URL:         answer.GetHTMLURL(),
+		Description: markdown.Convert(answer.GetBody(), answer.GetHTMLURL()),
+		Color:       c.ColorScheme.Color(DiscussionAnswered, true),
+		Fields: []discord.EmbedField{
+			{
+				Name:  "Answered by",
+				Value: markdown.ConvertHyperlink(answer.GetUser().GetLogin(), answer.GetUser().GetHTMLURL()),
+			},
+		},
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makeDiscussionUnansweredEmbed(ev *DiscussionEvent) discord.Embed {
+	return discord.Embed{
+		Title: fmt.Sprintf("Answer unmarked on discussion #%d", ev.GetDiscussion().GetNumber()),
+		URL:   ev.GetAnswer().GetHTMLURL(),
+		Color: c.ColorScheme.Color(DiscussionAnswered, false),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makeDiscussionDeletedEmbed(ev *DiscussionEvent) discord.Embed {
+	return discord.Embed{
+		Title: fmt.Sprintf("Discussion #%d deleted", ev.GetDiscussion().GetNumber()),
+		Color: c.ColorScheme.Color(DiscussionDeleted, true),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+/// END DiscussionEvent Discord embeds
+/// START DiscussionCommentEvent Discord embeds
+
+func (c *Config) makeDiscussionCommentEmbed(ev *DiscussionCommentEvent) discord.Embed {
+	d, comment := ev.GetDiscussion(), ev.GetComment()
+
+	title := fmt.Sprintf("Comment on discussion #%d", d.GetNumber())
+
+	var fields []discord.EmbedField
+	if parentID := comment.GetParentID(); parentID != 0 {
+		title = fmt.Sprintf("Reply on discussion #%d", d.GetNumber())
+		fields = append(fields, discord.EmbedField{
+			Name:  "In reply to",
+			Value: markdown.ConvertHyperlink("comment", fmt.Sprintf("%s#discussioncomment-%d", d.GetHTMLURL(), parentID)),
+		})
+	}
+
+	return discord.Embed{
+		Title:       title,
+		Description: markdown.Convert(comment.GetBody(), comment.GetHTMLURL()),
+		URL:         comment.GetHTMLURL(),
+		Color:       c.ColorScheme.Color(DiscussionCommented, true),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  comment.GetUser().GetHTMLURL(),
+			Name: comment.GetUser().GetLogin(),
+			Icon: comment.GetUser().GetAvatarURL(),
+		},
+		// Footer is used to store the comment ID
+		Footer: &discord.EmbedFooter{Text: strconv.FormatInt(comment.GetID(), 10)},
+	}
+}
+
+func (c *Config) makeDiscussionCommentDeletedEmbed(ev *DiscussionCommentEvent) discord.Embed {
+	return discord.Embed{
+		Title:       fmt.Sprintf("Deleted comment on discussion #%d", ev.GetDiscussion().GetNumber()),
+		Description: markdown.Convert(ev.GetComment().GetBody(), ""),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+		Color:  c.ColorScheme.Color(DiscussionCommentDeleted, true),
+		Footer: &discord.EmbedFooter{Text: strconv.FormatInt(ev.GetComment().GetID(), 10)},
+	}
+}
+
+/// END DiscussionCommentEvent Discord embeds
+/// START WorkflowRunEvent, CheckSuiteEvent and CheckRunEvent Discord embeds
+
+// maxStatusChecks is the maximum number of checks listed in a CI status embed.
+const maxStatusChecks = 25
+
+func (c *Config) makeCIStatusEmbed(pr *github.PullRequest, sha string, runs []*github.CheckRun) discord.Embed {
+	var description strings.Builder
+	var pending, failed bool
+	for i, run := range runs {
+		switch {
+		case run.GetStatus() != "completed":
+			pending = true
+		case checkFailed(run.GetConclusion()):
+			failed = true
+		}
+
+		if i == maxStatusChecks {
+			fmt.Fprintf(&description, "… and %d more", len(runs)-maxStatusChecks)
+			continue
+		} else if i > maxStatusChecks {
+			continue
+		}
+
+		fmt.Fprintf(&description, "%s %s", checkEmoji(run.GetStatus(), run.GetConclusion()), markdown.ConvertHyperlink(run.GetName(), run.GetHTMLURL()))
+		if run.StartedAt != nil && run.CompletedAt != nil {
+			fmt.Fprintf(&description, " · %s", run.GetCompletedAt().Sub(run.GetStartedAt().Time).Round(time.Second))
+		}
+		description.WriteString("\n")
+	}
+
+	if len(runs) == 0 {
+		description.WriteString("No checks yet.")
+	}
+
+	color := c.ColorScheme.Color(CIStatus, !failed)
+	if pending && !failed {
+		color = c.ColorScheme.Color(CIPending, true)
+	}
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("CI status of `%s`", shortSHA(sha)),
+		URL:         pr.GetHTMLURL() + "/checks",
+		Description: strings.TrimSpace(description.String()),
+		Color:       color,
+	}
+}
+
+// checkLink is a job or check run linked by its URL.
+type checkLink struct {
+	name string
+	url  string
+}
+
+func (c *Config) makeCIFailedEmbed(name, url, sha string, failed []checkLink) discord.Embed {
+	var description strings.Builder
+	for i, check := range failed {
+		if i == maxStatusChecks {
+			fmt.Fprintf(&description, "… and %d more", len(failed)-maxStatusChecks)
+			break
+		}
+		fmt.Fprintf(&description, "❌ %s\n", markdown.ConvertHyperlink(check.name, check.url))
+	}
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("%s failed on `%s`", name, shortSHA(sha)),
+		URL:         url,
+		Description: strings.TrimSpace(description.String()),
+		Color:       c.ColorScheme.Color(CIFailed, false),
+	}
+}
+
+// checkEmoji returns the emoji of the state of a check run or job.
+func checkEmoji(status, conclusion string) string {
+	switch status {
+	case "queued", "waiting", "requested", "pending":
+		return "⏳"
+	case "in_progress":
+		return "🔄"
+	}
+
+	switch conclusion {
+	case "success":
+		return "✅"
+	case "failure", "timed_out", "startup_failure":
+		return "❌"
+	case "cancelled":
+		return "🚫"
+	case "skipped":
+		return "⏭️"
+	case "action_required":
+		return "⚠️"
+	default:
+		return "⚪"
+	}
+}
+
+/// END WorkflowRunEvent, CheckSuiteEvent and CheckRunEvent Discord embeds
+/// START StatusEvent Discord embeds
+
+func (c *Config) makeStatusEmbed(ev *github.StatusEvent) discord.Embed {
+	return discord.Embed{
+		Title:       fmt.Sprintf("[%s] %s: %s", ev.GetRepo().GetName(), ev.GetContext(), ev.GetState()),
+		URL:         ev.GetTargetURL(),
+		Description: ev.GetDescription(),
+		Color:       c.ColorScheme.Color(CommitStatus, !statusFailed(ev.GetState())),
+		Fields: []discord.EmbedField{
+			{
+				Name:   "Commit",
+				Value:  markdown.ConvertHyperlink("`"+shortSHA(ev.GetSHA())+"`", ev.GetCommit().GetHTMLURL()),
+				Inline: true,
+			},
+		},
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+/// END StatusEvent Discord embeds
+/// START DeploymentEvent and DeploymentStatusEvent Discord embeds
+
+func (c *Config) makeDeploymentEmbed(ev *github.DeploymentEvent) discord.Embed {
+	deployment := ev.GetDeployment()
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("[%s] Deployment to %s created", ev.GetRepo().GetName(), deployment.GetEnvironment()),
+		URL:         ev.GetRepo().GetHTMLURL() + "/deployments",
+		Description: deployment.GetDescription(),
+		Color:       c.ColorScheme.Color(DeploymentCreated, true),
+		Fields:      makeDeploymentFields(ev.GetRepo(), deployment),
+		Author: &discord.EmbedAuthor{
+			URL:  deployment.GetCreator().GetHTMLURL(),
+			Name: deployment.GetCreator().GetLogin(),
+			Icon: deployment.GetCreator().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makeDeploymentStatusEmbed(ev *github.DeploymentStatusEvent) discord.Embed {
+	deployment, status := ev.GetDeployment(), ev.GetDeploymentStatus()
+
+	logURL := status.GetLogURL()
+	if logURL == "" {
+		logURL = status.GetTargetURL()
+	}
+
+	fields := makeDeploymentFields(ev.GetRepo(), deployment)
+	fields = append(fields, discord.EmbedField{
+		Name:   "State",
+		Value:  status.GetState(),
+		Inline: true,
+	})
+
+	if logURL != "" {
+		fields = append(fields, discord.EmbedField{
+			Name:   "Logs",
+			Value:  markdown.ConvertHyperlink("logs", logURL),
+			Inline: true,
+		})
+	}
+
+	if url := status.GetEnvironmentURL(); url != "" {
+		fields = append(fields, discord.EmbedField{
+			Name:  "Deployed to",
+			Value: url,
+		})
+	}
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("[%s] Deployment to %s: %s", ev.GetRepo().GetName(), deployment.GetEnvironment(), status.GetState()),
+		URL:         logURL,
+		Description: status.GetDescription(),
+		Color:       c.ColorScheme.Color(DeploymentStatusChanged, !statusFailed(status.GetState())),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  status.GetCreator().GetHTMLURL(),
+			Name: status.GetCreator().GetLogin(),
+			Icon: status.GetCreator().GetAvatarURL(),
+		},
+	}
+}
+
+func makeDeploymentFields(repo *github.Repository, deployment *github.Deployment) []discord.EmbedField {
+	return []discord.EmbedField{
+		{
+			Name:   "Environment",
+			Value:  deployment.GetEnvironment(),
+			Inline: true,
+		},
+		{
+			Name:   "Ref",
+			Value:  fmt.Sprintf("`%s` @ %s", deployment.GetRef(), markdown.ConvertHyperlink("`"+shortSHA(deployment.GetSHA())+"`", repo.GetHTMLURL()+"/commit/"+deployment.GetSHA())),
+			Inline: true,
+		},
+	}
+}
+
+// statusFailed returns whether the state of a commit or deployment status is a
+// failure.
+func statusFailed(state string) bool {
+	return state == "failure" || state == "error"
+}
+
+/// END DeploymentEvent and DeploymentStatusEvent Discord embeds
+/// START CreateEvent and DeleteEvent Discord embeds
+
+func (c *Config) makeRefCreatedEmbed(ev *github.CreateEvent) discord.Embed {
+	return discord.Embed{
+		Title: fmt.Sprintf("[%s] %s %s created", ev.GetRepo().GetName(), ev.GetRefType(), ev.GetRef()),
+		URL:   ev.GetRepo().GetHTMLURL() + "/tree/" + ev.GetRef(),
+		Color: c.ColorScheme.Color(RefCreated, true),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makeRefDeletedEmbed(ev *github.DeleteEvent) discord.Embed {
+	return discord.Embed{
+		Title: fmt.Sprintf("[%s] %s %s deleted", ev.GetRepo().GetName(), ev.GetRefType(), ev.GetRef()),
+		Color: c.ColorScheme.Color(RefDeleted, true),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+/// END CreateEvent and DeleteEvent Discord embeds
+/// START Security alert and advisory Discord embeds
+
+// maxAdvisoryPackages is the maximum number of affected packages listed in a
+// security advisory embed.
+const maxAdvisoryPackages = 10
+
+func (c *Config) makeDependabotAlertEmbed(ev *DependabotAlertEvent) discord.Embed {
+	alert := ev.GetAlert()
+	advisory, vuln := alert.GetSecurityAdvisory(), alert.GetSecurityVulnerability()
+
+	fields := []discord.EmbedField{
+		{
+			Name:   "Severity",
+			Value:  vuln.GetSeverity(),
+			Inline: true,
+		},
+		{
+			Name:   "Package",
+			Value:  formatPackage(alert.GetPackage()),
+			Inline: true,
+		},
+		{
+			Name:   "Affected versions",
+			Value:  "`" + vuln.GetVulnerableVersionRange() + "`",
+			Inline: true,
+		},
+	}
+
+	if patched := vuln.GetFirstPatchedVersion().GetIdentifier(); patched != "" {
+		fields = append(fields, discord.EmbedField{
+			Name:   "Fixed in",
+			Value:  "`" + patched + "`",
+			Inline: true,
+		})
+	}
+
+	if path := alert.GetManifestPath(); path != "" {
+		fields = append(fields, discord.EmbedField{
+			Name:   "Manifest",
+			Value:  markdown.ConvertHyperlink("`"+path+"`", ev.GetRepo().GetHTMLURL()+"/blob/HEAD/"+path),
+			Inline: true,
+		})
+	}
+
+	fields = append(fields, makeAdvisoryFields(advisory)...)
+
+	resolved := ev.GetAction() == "dismissed" || ev.GetAction() == "fixed" || ev.GetAction() == "auto_dismissed"
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("[%s] Dependabot alert #%d %s: %s", ev.GetRepo().GetName(), alert.GetNumber(), formatAction(ev.GetAction()), advisory.GetSummary()),
+		URL:         alert.GetHTMLURL(),
+		Description: markdown.Convert(advisory.GetDescription(), alert.GetHTMLURL()),
+		Color:       c.securityColor(vuln.GetSeverity(), resolved),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makeCodeScanningAlertEmbed(ev *github.CodeScanningAlertEvent) discord.Embed {
+	alert := ev.GetAlert()
+	rule := alert.GetRule()
+
+	// The security severity of security rules is more telling than their
+	// severity, which is "error" for most of them.
+	severity := rule.GetSecuritySeverityLevel()
+	if severity == "" {
+		severity = rule.GetSeverity()
+	}
+
+	fields := []discord.EmbedField{
+		{
+			Name:   "Severity",
+			Value:  severity,
+			Inline: true,
+		},
+		{
+			Name:   "Tool",
+			Value:  alert.GetTool().GetName(),
+			Inline: true,
+		},
+		{
+			Name:   "Rule",
+			Value:  "`" + rule.GetID() + "`",
+			Inline: true,
+		},
+	}
+
+	instance := alert.GetMostRecentInstance()
+	if loc := instance.GetLocation(); loc.GetPath() != "" {
+		url := fmt.Sprintf("%s/blob/%s/%s#L%d", ev.GetRepo().GetHTMLURL(), instance.GetCommitSHA(), loc.GetPath(), loc.GetStartLine())
+		fields = append(fields, discord.EmbedField{
+			Name:  "Location",
+			Value: markdown.ConvertHyperlink(fmt.Sprintf("`%s:%d`", loc.GetPath(), loc.GetStartLine()), url),
+		})
+	}
+
+	var cwes []string
+	for _, tag := range rule.Tags {
+		if cwe := parseCWETag(tag); cwe != "" {
+			cwes = append(cwes, cwe)
+		}
+	}
+	if len(cwes) > 0 {
+		fields = append(fields, discord.EmbedField{
+			Name:  "CWE",
+			Value: formatCWEs(cwes),
+		})
+	}
+
+	resolved := ev.GetAction() == "fixed" || ev.GetAction() == "closed_by_user"
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("[%s] Code scanning alert #%d %s: %s", ev.GetRepo().GetName(), alert.GetNumber(), formatAction(ev.GetAction()), rule.GetDescription()),
+		URL:         alert.GetHTMLURL(),
+		Description: instance.GetMessage().GetText(),
+		Color:       c.securityColor(severity, resolved),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makeSecretScanningAlertEmbed(ev *github.SecretScanningAlertEvent) discord.Embed {
+	alert := ev.GetAlert()
+
+	fields := []discord.EmbedField{
+		{
+			Name:   "Secret type",
+			Value:  alert.GetSecretType(),
+			Inline: true,
+		},
+		{
+			Name:   "State",
+			Value:  alert.GetState(),
+			Inline: true,
+		},
+	}
+
+	if resolution := alert.GetResolution(); resolution != "" {
+		fields = append(fields, discord.EmbedField{
+			Name:   "Resolution",
+			Value:  resolution,
+			Inline: true,
+		})
+	}
+
+	resolved := ev.GetAction() == "resolved" || ev.GetAction() == "revoked"
+
+	return discord.Embed{
+		Title: fmt.Sprintf("[%s] Secret scanning alert #%d %s: %s", ev.GetRepo().GetName(), alert.GetNumber(), formatAction(ev.GetAction()), alert.GetSecretType()),
+		URL:   alert.GetHTMLURL(),
+		// Leaked secrets are always treated as critical.
+		Color:  c.securityColor("critical", resolved),
+		Fields: fields,
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makeSecurityAdvisoryEmbed(ev *SecurityAdvisoryEvent) discord.Embed {
+	advisory := ev.GetSecurityAdvisory()
+	url := "https://github.com/advisories/" + advisory.GetGHSAID()
+
+	fields := []discord.EmbedField{
+		{
+			Name:   "Severity",
+			Value:  advisory.GetSeverity(),
+			Inline: true,
+		},
+	}
+
+	var packages []string
+	for i, vuln := range advisory.Vulnerabilities {
+		if i == maxAdvisoryPackages {
+			packages = append(packages, fmt.Sprintf("and %d more", len(advisory.Vulnerabilities)-i))
+			break
+		}
+
+		line := fmt.Sprintf("%s `%s`", formatPackage(vuln.GetPackage()), vuln.GetVulnerableVersionRange())
+		if patched := vuln.GetFirstPatchedVersion().GetIdentifier(); patched != "" {
+			line += fmt.Sprintf(", fixed in `%s`", patched)
+		}
+		packages = append(packages, line)
+	}
+	if len(packages) > 0 {
+		fields = append(fields, discord.EmbedField{
+			Name:  "Affected packages",
+			Value: strings.Join(packages, "\n"),
+		})
+	}
+
+	fields = append(fields, makeAdvisoryFields(advisory)...)
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("Security advisory %s %s: %s", advisory.GetGHSAID(), formatAction(ev.GetAction()), advisory.GetSummary()),
+		URL:         url,
+		Description: markdown.Convert(advisory.GetDescription(), url),
+		Color:       c.securityColor(advisory.GetSeverity(), ev.GetAction() == "withdrawn"),
+		Fields:      fields,
+	}
+}
+
+// makeAdvisoryFields makes the CWE and advisory link fields of a security
+// advisory.
+func makeAdvisoryFields(advisory *SecurityAdvisory) []discord.EmbedField {
+	var fields []discord.EmbedField
+
+	var cwes []string
+	for _, cwe := range advisory.GetCWEs() {
+		cwes = append(cwes, cwe.GetCWEID())
+	}
+	if len(cwes) > 0 {
+		fields = append(fields, discord.EmbedField{
+			Name:  "CWE",
+			Value: formatCWEs(cwes),
+		})
+	}
+
+	links := []string{
+		markdown.ConvertHyperlink(advisory.GetGHSAID(), "https://github.com/advisories/"+advisory.GetGHSAID()),
+	}
+	if cve := advisory.GetCVEID(); cve != "" {
+		links = append(links, markdown.ConvertHyperlink(cve, "https://nvd.nist.gov/vuln/detail/"+cve))
+	}
+	fields = append(fields, discord.EmbedField{
+		Name:  "Advisory",
+		Value: strings.Join(links, ", "),
+	})
+
+	return fields
+}
+
+// securityColor returns the color of a security alert or advisory of the given
+// severity, as named by GitHub's advisories ("moderate") or code scanning
+// tools ("warning").
+func (c *Config) securityColor(severity string, resolved bool) discord.Color {
+	if resolved {
+		return c.ColorScheme.Color(SecurityResolved, true)
+	}
+
+	switch strings.ToLower(severity) {
+	case "critical":
+		return c.ColorScheme.Color(SecurityCritical, true)
+	case "high", "error":
+		return c.ColorScheme.Color(SecurityHigh, true)
+	case "medium", "moderate", "warning":
+		return c.ColorScheme.Color(SecurityMedium, true)
+	default:
+		return c.ColorScheme.Color(SecurityLow, true)
+	}
+}
+
+func formatPackage(pkg *github.VulnerabilityPackage) string {
+	return fmt.Sprintf("`%s` (%s)", pkg.GetName(), pkg.GetEcosystem())
+}
+
+// formatAction formats a webhook action like "auto_dismissed" for titles.
+func formatAction(action string) string {
+	return strings.ReplaceAll(action, "_", " ")
+}
+
+// formatCWEs links CWE IDs like "CWE-79" to their definitions.
+func formatCWEs(ids []string) string {
+	links := make([]string, len(ids))
+	for i, id := range ids {
+		n := strings.TrimPrefix(id, "CWE-")
+		links[i] = markdown.ConvertHyperlink(id, "https://cwe.mitre.org/data/definitions/"+n+".html")
+	}
+	return strings.Join(links, ", ")
+}
+
+// parseCWETag parses a code scanning rule tag like "external/cwe/cwe-079" into
+// a CWE ID like "CWE-79". It returns an empty string for other tags.
+func parseCWETag(tag string) string {
+	const prefix = "external/cwe/cwe-"
+	if !strings.HasPrefix(tag, prefix) {
+		return ""
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(tag, prefix))
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("CWE-%d", id)
+}
+
+/// END Security alert and advisory Discord embeds
+/// START Repository activity Discord embeds
+
+// maxActivityNames is the maximum number of stargazers or forks listed in a
+// batched activity embed.
+const maxActivityNames = 20
+
+func (c *Config) makeStarEmbed(evs []*github.WatchEvent) discord.Embed {
+	// The latest event has the latest stargazer count.
+	last := evs[len(evs)-1]
+	repo := last.GetRepo()
+
+	users := make([]*github.User, len(evs))
+	for i, ev := range evs {
+		users[i] = ev.GetSender()
+	}
+
+	embed := discord.Embed{
+		URL:         repo.GetHTMLURL() + "/stargazers",
+		Description: fmt.Sprintf("%s now has %s.", repo.GetFullName(), plural(repo.GetStargazersCount(), "star")),
+		Color:       c.ColorScheme.Color(Starred, true),
+	}
+
+	if len(users) == 1 {
+		embed.Title = fmt.Sprintf("[%s] ⭐ Starred by %s", repo.GetName(), users[0].GetLogin())
+		embed.Author = &discord.EmbedAuthor{
+			URL:  users[0].GetHTMLURL(),
+			Name: users[0].GetLogin(),
+			Icon: users[0].GetAvatarURL(),
+		}
+		return embed
+	}
+
+	embed.Title = fmt.Sprintf("[%s] ⭐ %d new stargazers", repo.GetName(), len(users))
+	embed.Fields = []discord.EmbedField{{
+		Name:  "Stargazers",
+		Value: truncateNames(markdown.ConvertUsers(firstN(users, maxActivityNames)), len(users)),
+	}}
+	return embed
+}
+
+func (c *Config) makeForkEmbed(evs []*github.ForkEvent) discord.Embed {
+	last := evs[len(evs)-1]
+	repo := last.GetRepo()
+
+	embed := discord.Embed{
+		URL:         repo.GetHTMLURL() + "/forks",
+		Description: fmt.Sprintf("%s now has %s.", repo.GetFullName(), plural(repo.GetForksCount(), "fork")),
+		Color:       c.ColorScheme.Color(Forked, true),
+	}
+
+	if len(evs) == 1 {
+		embed.Title = fmt.Sprintf("[%s] 🍴 Forked to %s", repo.GetName(), last.GetForkee().GetFullName())
+		embed.URL = last.GetForkee().GetHTMLURL()
+		embed.Author = &discord.EmbedAuthor{
+			URL:  last.GetSender().GetHTMLURL(),
+			Name: last.GetSender().GetLogin(),
+			Icon: last.GetSender().GetAvatarURL(),
+		}
+		return embed
+	}
+
+	var forks []string
+	for _, ev := range firstN(evs, maxActivityNames) {
+		forks = append(forks, markdown.ConvertHyperlink(ev.GetForkee().GetFullName(), ev.GetForkee().GetHTMLURL()))
+	}
+
+	embed.Title = fmt.Sprintf("[%s] 🍴 %d new forks", repo.GetName(), len(evs))
+	embed.Fields = []discord.EmbedField{{
+		Name:  "Forks",
+		Value: truncateNames(strings.Join(forks, ", "), len(evs)),
+	}}
+	return embed
+}
+
+func (c *Config) makeMemberEmbed(ev *github.MemberEvent) discord.Embed {
+	var title string
+	switch ev.GetAction() {
+	case "added":
+		title = fmt.Sprintf("[%s] %s added as a collaborator", ev.GetRepo().GetName(), ev.GetMember().GetLogin())
+	case "removed":
+		title = fmt.Sprintf("[%s] %s removed as a collaborator", ev.GetRepo().GetName(), ev.GetMember().GetLogin())
+	default:
+		title = fmt.Sprintf("[%s] Permissions of %s changed", ev.GetRepo().GetName(), ev.GetMember().GetLogin())
+	}
+
+	return discord.Embed{
+		Title: title,
+		URL:   ev.GetMember().GetHTMLURL(),
+		Color: c.ColorScheme.Color(MemberChanged, ev.GetAction() != "removed"),
+		Thumbnail: &discord.EmbedThumbnail{
+			URL: ev.GetMember().GetAvatarURL(),
+		},
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+func (c *Config) makePublicEmbed(ev *github.PublicEvent) discord.Embed {
+	return discord.Embed{
+		Title:       fmt.Sprintf("🎉 %s is now public", ev.GetRepo().GetFullName()),
+		URL:         ev.GetRepo().GetHTMLURL(),
+		Description: ev.GetRepo().GetDescription(),
+		Color:       c.ColorScheme.Color(RepoPublicized, true),
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+}
+
+// makeSponsorshipEmbed makes the embed of a sponsorship. The sponsors of
+// private sponsorships are not shown, which is why the sender is not either.
+func (c *Config) makeSponsorshipEmbed(ev *SponsorshipEvent) discord.Embed {
+	sponsorship := ev.GetSponsorship()
+	sponsorable := sponsorship.GetSponsorable().GetLogin()
+
+	sponsor := "A private sponsor"
+	if user := sponsorship.GetSponsor(); user != nil {
+		sponsor = user.GetLogin()
+	}
+
+	var title string
+	switch ev.GetAction() {
+	case "created":
+		title = fmt.Sprintf("💖 %s started sponsoring %s", sponsor, sponsorable)
+	case "cancelled":
+		title = fmt.Sprintf("%s stopped sponsoring %s", sponsor, sponsorable)
+	case "tier_changed":
+		title = fmt.Sprintf("%s changed their sponsorship of %s", sponsor, sponsorable)
+	default:
+		title = fmt.Sprintf("Sponsorship of %s by %s %s", sponsorable, sponsor, formatAction(ev.GetAction()))
+	}
+
+	embed := discord.Embed{
+		Title: title,
+		URL:   "https://github.com/sponsors/" + sponsorable,
+		Color: c.ColorScheme.Color(Sponsored, ev.GetAction() != "cancelled"),
+	}
+
+	if tier := sponsorship.GetTierName(); tier != "" {
+		if sponsorship.GetTierOneTime() {
+			tier += " (one time)"
+		}
+		embed.Fields = []discord.EmbedField{{
+			Name:  "Tier",
+			Value: tier,
+		}}
+	}
+
+	if user := sponsorship.GetSponsor(); user != nil {
+		embed.Author = &discord.EmbedAuthor{
+			URL:  user.GetHTMLURL(),
+			Name: user.GetLogin(),
+			Icon: user.GetAvatarURL(),
+		}
+	}
+
+	return embed
+}
+
+// truncateNames appends how many of total names were left out of the
+// maxActivityNames listed in names.
+func truncateNames(names string, total int) string {
+	if total > maxActivityNames {
+		names += fmt.Sprintf(" and %d more", total-maxActivityNames)
+	}
+	return names
+}
+
+func firstN[T any](s []T, n int) []T {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+/// END Repository activity Discord embeds
+/// START MilestoneEvent and LabelEvent Discord embeds
+
+// progressBarWidth is the number of blocks in a milestone progress bar.
+const progressBarWidth = 10
+
+func (c *Config) makeMilestoneEmbed(ev *github.MilestoneEvent) discord.Embed {
+	m := ev.GetMilestone()
+	repo := ev.GetRepo().GetName()
+
+	var title string
+	var key ColorSchemeKey
+	switch ev.GetAction() {
+	case "created":
+		title, key = fmt.Sprintf("[%s] Milestone %s created", repo, m.GetTitle()), MilestoneCreated
+	case "closed":
+		title, key = fmt.Sprintf("[%s] Milestone %s closed", repo, m.GetTitle()), MilestoneClosed
+	case "opened":
+		title, key = fmt.Sprintf("[%s] Milestone %s reopened", repo, m.GetTitle()), MilestoneReopened
+	case "deleted":
+		title, key = fmt.Sprintf("[%s] Milestone %s deleted", repo, m.GetTitle()), MilestoneDeleted
+	default:
+		title, key = fmt.Sprintf("[%s] Milestone %s edited", repo, m.GetTitle()), MilestoneEdited
+		if from := ev.GetChanges().GetTitle(); from != nil {
+			title = fmt.Sprintf("[%s] Milestone %s renamed to %s", repo, from.GetFrom(), m.GetTitle())
+		}
+	}
+
+	fields := []discord.EmbedField{
+		{
+			Name:  "Progress",
+			Value: makeProgressBar(m.GetOpenIssues(), m.GetClosedIssues()),
+		},
+	}
+
+	if m.DueOn != nil {
+		fields = append(fields, discord.EmbedField{
+			Name:   "Due",
+			Value:  m.GetDueOn().Format("2006-01-02"),
+			Inline: true,
+		})
+	}
+
+	embed := discord.Embed{
+		Title:       title,
+		URL:         m.GetHTMLURL(),
+		Description: markdown.Convert(m.GetDescription(), m.GetHTMLURL()),
+		Color:       c.ColorScheme.Color(key, true),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+
+	if ev.GetAction() == "deleted" {
+		embed.URL = ""
+	}
+
+	return embed
+}
+
+// makeProgressBar renders the progress of a milestone, e.g.
+// "▓▓▓▓▓▓░░░░ 60% (3 of 5 closed)".
+func makeProgressBar(open, closed int) string {
+	total := open + closed
+	if total == 0 {
+		return "No issues"
+	}
+
+	done := closed * progressBarWidth / total
+	return fmt.Sprintf("%s%s %d%% (%d of %d closed)",
+		strings.Repeat("▓", done), strings.Repeat("░", progressBarWidth-done),
+		closed*100/total, closed, total)
+}
+
+func (c *Config) makeLabelEmbed(ev *github.LabelEvent) discord.Embed {
+	label := ev.GetLabel()
+
+	fields := []discord.EmbedField{
+		{
+			Name:   "Color",
+			Value:  "#" + label.GetColor(),
+			Inline: true,
+		},
+	}
+
+	embed := discord.Embed{
+		Title:       fmt.Sprintf("[%s] Label %s %s", ev.GetRepo().GetName(), label.GetName(), ev.GetAction()),
+		Description: label.GetDescription(),
+		Color:       c.labelColor(label),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}
+
+	if ev.GetAction() != "deleted" {
+		embed.URL = ev.GetRepo().GetHTMLURL() + "/labels/" + url.PathEscape(label.GetName())
+	}
+
+	return embed
+}
+
+// labelColor returns the color of label, or the LabelChanged color if it has
+// none.
+func (c *Config) labelColor(label *github.Label) discord.Color {
+	color, err := strconv.ParseUint(label.GetColor(), 16, 24)
+	if err != nil {
+		return c.ColorScheme.Color(LabelChanged, true)
+	}
+	return discord.Color(color)
+}
+
+/// END MilestoneEvent and LabelEvent Discord embeds
+/// START CommitCommentEvent Discord embeds
+
+func (c *Config) makeCommitCommentEmbed(ev *CommitCommentEvent) discord.Embed {
+	comment := ev.GetComment()
+	sha := comment.GetCommitID()
+	commitURL := ev.GetRepo().GetHTMLURL() + "/commit/" + sha
+
+	fields := []discord.EmbedField{
+		{
+			Name:   "Commit",
+			Value:  markdown.ConvertHyperlink("`"+shortSHA(sha)+"`", commitURL),
+			Inline: true,
+		},
+	}
+
+	if path := comment.GetPath(); path != "" {
+		file := "`" + path + "`"
+		fileURL := ev.GetRepo().GetHTMLURL() + "/blob/" + sha + "/" + path
+		if line := comment.GetLine(); line != 0 {
+			file = fmt.Sprintf("`%s:%d`", path, line)
+			fileURL += fmt.Sprintf("#L%d", line)
+		}
+
+		fields = append(fields, discord.EmbedField{
+			Name:   "File",
+			Value:  markdown.ConvertHyperlink(file, fileURL),
+			Inline: true,
+		})
+	}
+
+	return discord.Embed{
+		Title:       fmt.Sprintf("[%s] Comment on commit %s", ev.GetRepo().GetName(), shortSHA(sha)),
+		URL:         comment.GetHTMLURL(),
+		Description: markdown.Convert(comment.GetBody(), comment.GetHTMLURL()),
+		Color:       c.ColorScheme.Color(CommitCommented, true),
+		Fields:      fields,
+		Author: &discord.EmbedAuthor{
+			URL:  comment.GetUser().GetHTMLURL(),
+			Name: comment.GetUser().GetLogin(),
+			Icon: comment.GetUser().GetAvatarURL(),
+		},
+	}
+}
+
+/// END CommitCommentEvent Discord embeds
+/// START GollumEvent Discord embeds
+
+const (
+	// maxWikiPages is the maximum number of pages listed in a wiki embed.
+	maxWikiPages = 10
+	// maxWikiDiffs is the maximum number of page diffs sent along a wiki
+	// embed.
+	maxWikiDiffs = 4
+	// maxWikiDiffSize is the maximum size of a page diff, which keeps the
+	// message within Discord's limit of 6000 characters across embeds.
+	maxWikiDiffSize = 1000
+)
+
+// makeWikiEmbeds makes the summary embed of a push to the wiki, followed by
+// an embed for each page with a diff in diffs.
+func (c *Config) makeWikiEmbeds(ev *github.GollumEvent, diffs map[*github.Page]string) []discord.Embed {
+	var fields []discord.EmbedField
+	for i, page := range ev.Pages {
+		if i == maxWikiPages {
+			fields = append(fields, discord.EmbedField{
+				Name:  "…",
+				Value: fmt.Sprintf("and %d more", len(ev.Pages)-i),
+			})
+			break
+		}
+
+		value := markdown.ConvertHyperlink(page.GetTitle(), page.GetHTMLURL())
+		if summary := page.GetSummary(); summary != "" {
+			value += ": " + summary
+		}
+
+		// Pages are either "created" or "edited".
+		action := page.GetAction()
+		if action != "" {
+			action = strings.ToUpper(action[:1]) + action[1:]
+		}
+
+		fields = append(fields, discord.EmbedField{
+			Name:  action,
+			Value: value,
+		})
+	}
+
+	embeds := []discord.Embed{{
+		Title:  fmt.Sprintf("[%s] %s changed in the wiki", ev.GetRepo().GetName(), plural(len(ev.Pages), "page")),
+		URL:    ev.GetRepo().GetHTMLURL() + "/wiki",
+		Color:  c.ColorScheme.Color(WikiChanged, true),
+		Fields: fields,
+		Author: &discord.EmbedAuthor{
+			URL:  ev.GetSender().GetHTMLURL(),
+			Name: ev.GetSender().GetLogin(),
+			Icon: ev.GetSender().GetAvatarURL(),
+		},
+	}}
+
+	for _, page := range ev.Pages {
+		diff, ok := diffs[page]
+		if !ok || diff == "" {
+			continue
+		}
+		if len(embeds) > maxWikiDiffs {
+			break
+		}
+
+		if len(diff) > maxWikiDiffSize {
+			diff = diff[:strings.LastIndex(diff[:maxWikiDiffSize], "\n")+1] + "…\n"
+		}
+
+		embeds = append(embeds, discord.Embed{
+			Title:       page.GetTitle(),
+			URL:         page.GetHTMLURL() + "/_compare/" + page.GetSHA(),
+			Description: "```diff\n" + diff + "```",
+			Color:       c.ColorScheme.Color(WikiChanged, true),
+		})
+	}
+
+	return embeds
+}
+
+/// END GollumEvent Discord embeds
+/// START ProjectsV2ItemEvent and ProjectCardEvent Discord embeds
+
+// projectStatusName is the name of the field of the initial embeds of issues
+// and pull requests that shows their status on a project board.
+const projectStatusName = "Project status"
+
+func (c *Config) makeProjectItemEmbed(title string, item projectItem, sender *github.User) discord.Embed {
+	return discord.Embed{
+		Title: title,
+		URL:   item.projectURL,
+		Color: c.ColorScheme.Color(ProjectItemMoved, true),
+		Author: &discord.EmbedAuthor{
+			URL:  sender.GetHTMLURL(),
+			Name: sender.GetLogin(),
+			Icon: sender.GetAvatarURL(),
+		},
+	}
+}
+
+// makeProjectStatusField makes the Project status field of item. It returns
+// nil if the item is not on a project.
+func makeProjectStatusField(item projectItem) *discord.EmbedField {
+	if item.projectURL == "" {
+		return nil
+	}
+
+	status := item.status
+	if status == "" {
+		status = "No status"
+	}
+
+	return &discord.EmbedField{
+		Name:  projectStatusName,
+		Value: fmt.Sprintf("%s: %s", markdown.ConvertHyperlink(item.project, item.projectURL), status),
+	}
+}
+
+// withProjectStatus returns fields with their Project status field replaced
+// by status, or removed if status is nil.
+func withProjectStatus(fields []discord.EmbedField, status *discord.EmbedField) []discord.EmbedField {
+	fields = slices.Filter(fields, func(f *discord.EmbedField) bool {
+		return f.Name != projectStatusName
+	})
+	if status != nil {
+		fields = append(fields, *status)
+	}
+	return fields
+}
+
+/// END ProjectsV2ItemEvent and ProjectCardEvent Discord embeds
+
+// shortSHA abbreviates a commit SHA the way GitHub does.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// commitSHA returns the SHA of a pushed commit. Webhook payloads call it the
+// ID, while the Events API calls it the SHA.
+func commitSHA(commit *github.HeadCommit) string {
+	if id := commit.GetID(); id != "" {
+		return id
+	}
+	return commit.GetSHA()
+}
+
+// commitLine formats a commit as a line linking its short SHA, followed by the
+// first line of its message and its author.
+func commitLine(sha, url, msg, author string) string {
+	subject := firstLine(msg)
+	if runes := []rune(subject); len(runes) > maxCommitSubjectLen {
+		subject = string(runes[:maxCommitSubjectLen-1]) + "…"
+	}
+
+	return fmt.Sprintf("%s %s - %s\n",
+		markdown.ConvertHyperlink("`"+shortSHA(sha)+"`", url),
+		subject,
+		author,
+	)
+}
+
+// maxCommitSubjectLen is the maximum length of a commit subject in a commit
+// line.
+const maxCommitSubjectLen = 100
+
+// maxDescriptionLen is the maximum length of an embed description. Discord
+// rejects messages with longer ones.
+const maxDescriptionLen = 4096
+
+// formatCommitLines lists the commit lines of the first commits, as many as fit
+// within an embed description, followed by the number of commits left out of
+// total.
+func formatCommitLines(lines []string, total int) string {
+	// Leave room for the last line counting the commits left out.
+	const maxLen = maxDescriptionLen - len("… and 1000000 more")
+
+	var description strings.Builder
+	var shown int
+	for _, line := range lines {
+		if shown == maxPushCommits || description.Len()+len(line) > maxLen {
+			break
+		}
+
+		description.WriteString(line)
+		shown++
+	}
+
+	if shown < total {
+		fmt.Fprintf(&description, "… and %d more", total-shown)
+	}
+
+	return strings.TrimSpace(description.String())
+}
+
+// firstLine returns the first line of a commit message.
+func firstLine(msg string) string {
+	line, _, _ := strings.Cut(msg, "\n")
+	return strings.TrimSpace(line)
+}
+
+// commitAuthorName returns the GitHub login of a commit author, or their git
+// name if the commit is not linked to a GitHub user.
+func commitAuthorName(author *github.CommitAuthor) string {
+	if login := author.GetLogin(); login != "" {
+		return login
+	}
+	return author.GetName()
+}
+
 // checkPR checks if an issue happens to be a pull request
 func checkPR(issue *github.Issue) bool {
 	return issue.GetPullRequestLinks() != nil
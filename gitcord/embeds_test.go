@@ -0,0 +1,138 @@
+package gitcord
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+)
+
+func TestFormatReleaseAssets(t *testing.T) {
+	makeAssets := func(n int, url string) []*github.ReleaseAsset {
+		assets := make([]*github.ReleaseAsset, n)
+		for i := range assets {
+			assets[i] = &github.ReleaseAsset{
+				Name:               github.String(fmt.Sprintf("asset-%d.tar.gz", i)),
+				BrowserDownloadURL: github.String(url),
+			}
+		}
+		return assets
+	}
+
+	longURL := "https://github.com/o/r/releases/download/v1.0.0/" + strings.Repeat("x", 200)
+
+	type test struct {
+		name   string
+		assets []*github.ReleaseAsset
+		lines  int
+		more   string
+	}
+
+	tests := []test{
+		{
+			name:   "few",
+			assets: makeAssets(3, "https://example.com/a"),
+			lines:  3,
+		},
+		{
+			name:   "too many",
+			assets: makeAssets(12, "https://example.com/a"),
+			lines:  maxReleaseAssets + 1,
+			more:   "… and 2 more",
+		},
+		{
+			name:   "too long",
+			assets: makeAssets(10, longURL),
+			lines:  3 + 1,
+			more:   "… and 7 more",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value := formatReleaseAssets(test.assets)
+			if len(value) > maxFieldLen {
+				t.Errorf("value is %d bytes long", len(value))
+			}
+
+			lines := strings.Split(value, "\n")
+			if len(lines) != test.lines {
+				t.Errorf("got %d lines, want %d:\n%s", len(lines), test.lines, value)
+			}
+
+			if test.more != "" && lines[len(lines)-1] != test.more {
+				t.Errorf("unexpected last line %q, want %q", lines[len(lines)-1], test.more)
+			}
+		})
+	}
+}
+
+func TestFormatReason(t *testing.T) {
+	tests := map[string]string{
+		"MERGE_CONFLICT":   "merge conflict",
+		"ci_failure":       "ci failure",
+		"manually_removed": "manually removed",
+		"":                 "",
+	}
+
+	for reason, want := range tests {
+		if got := formatReason(reason); got != want {
+			t.Errorf("formatReason(%q) = %q, want %q", reason, got, want)
+		}
+	}
+}
+
+func TestMakePREmbedDraft(t *testing.T) {
+	// Configurations only hold the colors overridden by the user.
+	var cfg Config
+
+	draft := &github.PullRequestEvent{PullRequest: &github.PullRequest{Number: github.Int(12), Draft: github.Bool(true)}}
+	embed := cfg.makePREmbed(draft)
+	if len(embed.Fields) == 0 || embed.Fields[0].Name != "Draft" {
+		t.Errorf("missing draft field in %+v", embed.Fields)
+	}
+	if embed.Color != 0x6E7681 {
+		t.Errorf("unexpected draft color %06X", embed.Color)
+	}
+
+	ready := &github.PullRequestEvent{PullRequest: &github.PullRequest{Number: github.Int(12)}}
+	embed = cfg.makePREmbed(ready)
+	for _, field := range embed.Fields {
+		if field.Name == "Draft" {
+			t.Error("unexpected draft field of a ready pull request")
+		}
+	}
+	if embed.Color != DefaultStatusColors.Success {
+		t.Errorf("unexpected color %06X of a ready pull request", embed.Color)
+	}
+}
+
+func TestMakePushEmbedLong(t *testing.T) {
+	var cfg Config
+
+	commits := make([]*github.HeadCommit, 12)
+	for i := range commits {
+		commits[i] = &github.HeadCommit{
+			ID:      github.String(fmt.Sprintf("%040d", i)),
+			URL:     github.String("https://github.com/o/r/commit/" + strings.Repeat("x", 1000)),
+			Message: github.String(strings.Repeat("long subject ", 100) + "\n\nbody"),
+		}
+	}
+
+	embed := cfg.makePushEmbed(&github.PushEvent{Ref: github.String("refs/heads/main"), Commits: commits})
+	if len(embed.Description) > maxDescriptionLen {
+		t.Errorf("description is %d bytes long", len(embed.Description))
+	}
+
+	lines := strings.Split(embed.Description, "\n")
+	if len(lines) != 3+1 {
+		t.Errorf("expected 3 commits and a last line, got %d lines", len(lines))
+	}
+	if last := lines[len(lines)-1]; last != "… and 9 more" {
+		t.Errorf("unexpected last line %q", last)
+	}
+	if !strings.Contains(lines[0], "…") {
+		t.Errorf("commit subject in %q is not shortened", lines[0])
+	}
+}
@@ -0,0 +1,825 @@
+package gitcord
+
+import (
+	"encoding/json"
+
+	"github.com/google/go-github/v47/github"
+)
+
+// parsePayload parses the payload of ev like ev.ParsePayload, except for the
+// event types that go-github lacks or gets wrong, which are parsed into the
+// types below.
+func parsePayload(ev *github.Event) (any, error) {
+	var payload any
+	switch ev.GetType() {
+	case "DiscussionEvent":
+		payload = &DiscussionEvent{}
+	case "DiscussionCommentEvent":
+		payload = &DiscussionCommentEvent{}
+	case "DependabotAlertEvent":
+		payload = &DependabotAlertEvent{}
+	case "SecurityAdvisoryEvent":
+		payload = &SecurityAdvisoryEvent{}
+	case "SponsorshipEvent":
+		payload = &SponsorshipEvent{}
+	case "CommitCommentEvent":
+		payload = &CommitCommentEvent{}
+	case "ProjectsV2ItemEvent":
+		payload = &ProjectsV2ItemEvent{}
+	case "ProjectCardEvent":
+		payload = &ProjectCardEvent{}
+	case "PullRequestEvent":
+		payload = &PullRequestEvent{}
+	case "MergeGroupEvent":
+		payload = &MergeGroupEvent{}
+	default:
+		return ev.ParsePayload()
+	}
+
+	if err := json.Unmarshal(ev.GetRawPayload(), payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// DiscussionEvent is triggered when a discussion is created, edited, answered
+// or otherwise changed. It replaces github.DiscussionEvent, which cannot parse
+// answered discussions and lacks their answer.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#discussion
+type DiscussionEvent struct {
+	// Action is the action that was performed, e.g. "created", "edited",
+	// "answered" or "category_changed".
+	Action     *string     `json:"action,omitempty"`
+	Discussion *Discussion `json:"discussion,omitempty"`
+	// Answer is the chosen answer of "answered" events and the previous
+	// answer of "unanswered" events.
+	Answer       *DiscussionComment   `json:"answer,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+}
+
+// DiscussionCommentEvent is triggered when a comment on a discussion, or a
+// reply to such a comment, is created, edited or deleted. go-github does not
+// define it.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#discussion_comment
+type DiscussionCommentEvent struct {
+	// Action is the action that was performed: "created", "edited" or
+	// "deleted".
+	Action       *string              `json:"action,omitempty"`
+	Comment      *DiscussionComment   `json:"comment,omitempty"`
+	Discussion   *Discussion          `json:"discussion,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+}
+
+// Discussion is a discussion in a DiscussionEvent or DiscussionCommentEvent.
+type Discussion struct {
+	ID             *int64                     `json:"id,omitempty"`
+	Number         *int                       `json:"number,omitempty"`
+	Title          *string                    `json:"title,omitempty"`
+	Body           *string                    `json:"body,omitempty"`
+	HTMLURL        *string                    `json:"html_url,omitempty"`
+	User           *github.User               `json:"user,omitempty"`
+	State          *string                    `json:"state,omitempty"`
+	Locked         *bool                      `json:"locked,omitempty"`
+	Comments       *int                       `json:"comments,omitempty"`
+	Labels         []*github.Label            `json:"labels,omitempty"`
+	Category       *github.DiscussionCategory `json:"category,omitempty"`
+	AnswerHTMLURL  *string                    `json:"answer_html_url,omitempty"`
+	AnswerChosenAt *github.Timestamp          `json:"answer_chosen_at,omitempty"`
+	AnswerChosenBy *github.User               `json:"answer_chosen_by,omitempty"`
+}
+
+// DiscussionComment is a comment on a discussion, or a reply to one.
+type DiscussionComment struct {
+	ID      *int64       `json:"id,omitempty"`
+	HTMLURL *string      `json:"html_url,omitempty"`
+	Body    *string      `json:"body,omitempty"`
+	User    *github.User `json:"user,omitempty"`
+	// ParentID is the ID of the comment that this comment replies to, if
+	// any.
+	ParentID *int64 `json:"parent_id,omitempty"`
+}
+
+func (e *DiscussionEvent) GetAction() string {
+	if e == nil || e.Action == nil {
+		return ""
+	}
+	return *e.Action
+}
+
+func (e *DiscussionEvent) GetDiscussion() *Discussion {
+	if e == nil {
+		return nil
+	}
+	return e.Discussion
+}
+
+func (e *DiscussionEvent) GetAnswer() *DiscussionComment {
+	if e == nil {
+		return nil
+	}
+	return e.Answer
+}
+
+func (e *DiscussionEvent) GetRepo() *github.Repository {
+	if e == nil {
+		return nil
+	}
+	return e.Repo
+}
+
+func (e *DiscussionEvent) GetSender() *github.User {
+	if e == nil {
+		return nil
+	}
+	return e.Sender
+}
+
+func (e *DiscussionCommentEvent) GetAction() string {
+	if e == nil || e.Action == nil {
+		return ""
+	}
+	return *e.Action
+}
+
+func (e *DiscussionCommentEvent) GetComment() *DiscussionComment {
+	if e == nil {
+		return nil
+	}
+	return e.Comment
+}
+
+func (e *DiscussionCommentEvent) GetDiscussion() *Discussion {
+	if e == nil {
+		return nil
+	}
+	return e.Discussion
+}
+
+func (e *DiscussionCommentEvent) GetRepo() *github.Repository {
+	if e == nil {
+		return nil
+	}
+	return e.Repo
+}
+
+func (e *DiscussionCommentEvent) GetSender() *github.User {
+	if e == nil {
+		return nil
+	}
+	return e.Sender
+}
+
+func (d *Discussion) GetID() int64 {
+	if d == nil || d.ID == nil {
+		return 0
+	}
+	return *d.ID
+}
+
+func (d *Discussion) GetNumber() int {
+	if d == nil || d.Number == nil {
+		return 0
+	}
+	return *d.Number
+}
+
+func (d *Discussion) GetTitle() string {
+	if d == nil || d.Title == nil {
+		return ""
+	}
+	return *d.Title
+}
+
+func (d *Discussion) GetBody() string {
+	if d == nil || d.Body == nil {
+		return ""
+	}
+	return *d.Body
+}
+
+func (d *Discussion) GetHTMLURL() string {
+	if d == nil || d.HTMLURL == nil {
+		return ""
+	}
+	return *d.HTMLURL
+}
+
+func (d *Discussion) GetUser() *github.User {
+	if d == nil {
+		return nil
+	}
+	return d.User
+}
+
+func (d *Discussion) GetState() string {
+	if d == nil || d.State == nil {
+		return ""
+	}
+	return *d.State
+}
+
+func (d *Discussion) GetLocked() bool {
+	if d == nil || d.Locked == nil {
+		return false
+	}
+	return *d.Locked
+}
+
+func (d *Discussion) GetCategory() *github.DiscussionCategory {
+	if d == nil {
+		return nil
+	}
+	return d.Category
+}
+
+func (d *Discussion) GetAnswerHTMLURL() string {
+	if d == nil || d.AnswerHTMLURL == nil {
+		return ""
+	}
+	return *d.AnswerHTMLURL
+}
+
+func (d *Discussion) GetAnswerChosenBy() *github.User {
+	if d == nil {
+		return nil
+	}
+	return d.AnswerChosenBy
+}
+
+func (c *DiscussionComment) GetID() int64 {
+	if c == nil || c.ID == nil {
+		return 0
+	}
+	return *c.ID
+}
+
+func (c *DiscussionComment) GetHTMLURL() string {
+	if c == nil || c.HTMLURL == nil {
+		return ""
+	}
+	return *c.HTMLURL
+}
+
+func (c *DiscussionComment) GetBody() string {
+	if c == nil || c.Body == nil {
+		return ""
+	}
+	return *c.Body
+}
+
+func (c *DiscussionComment) GetUser() *github.User {
+	if c == nil {
+		return nil
+	}
+	return c.User
+}
+
+func (c *DiscussionComment) GetParentID() int64 {
+	if c == nil || c.ParentID == nil {
+		return 0
+	}
+	return *c.ParentID
+}
+
+// DependabotAlertEvent is triggered when a Dependabot alert is created,
+// dismissed, fixed or reopened. go-github does not define it.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#dependabot_alert
+type DependabotAlertEvent struct {
+	// Action is the action that was performed, e.g. "created", "dismissed",
+	// "fixed" or "reopened".
+	Action       *string              `json:"action,omitempty"`
+	Alert        *DependabotAlert     `json:"alert,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+}
+
+// DependabotAlert is a Dependabot alert about a vulnerable dependency.
+type DependabotAlert struct {
+	Number     *int    `json:"number,omitempty"`
+	State      *string `json:"state,omitempty"`
+	HTMLURL    *string `json:"html_url,omitempty"`
+	Dependency *struct {
+		Package      *github.VulnerabilityPackage `json:"package,omitempty"`
+		ManifestPath *string                      `json:"manifest_path,omitempty"`
+	} `json:"dependency,omitempty"`
+	SecurityAdvisory      *SecurityAdvisory             `json:"security_advisory,omitempty"`
+	SecurityVulnerability *github.AdvisoryVulnerability `json:"security_vulnerability,omitempty"`
+}
+
+// SecurityAdvisoryEvent is triggered when a security advisory is published,
+// updated or withdrawn in the GitHub Advisory Database. It replaces
+// github.SecurityAdvisoryEvent, whose advisories lack their CWEs.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#security_advisory
+type SecurityAdvisoryEvent struct {
+	// Action is the action that was performed: "published", "updated",
+	// "performed" or "withdrawn".
+	Action           *string              `json:"action,omitempty"`
+	SecurityAdvisory *SecurityAdvisory    `json:"security_advisory,omitempty"`
+	Installation     *github.Installation `json:"installation,omitempty"`
+}
+
+// SecurityAdvisory is a security advisory of the GitHub Advisory Database.
+type SecurityAdvisory struct {
+	GHSAID          *string                         `json:"ghsa_id,omitempty"`
+	CVEID           *string                         `json:"cve_id,omitempty"`
+	Summary         *string                         `json:"summary,omitempty"`
+	Description     *string                         `json:"description,omitempty"`
+	Severity        *string                         `json:"severity,omitempty"`
+	CWEs            []*CWE                          `json:"cwes,omitempty"`
+	References      []*github.AdvisoryReference     `json:"references,omitempty"`
+	Vulnerabilities []*github.AdvisoryVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+// CWE is a Common Weakness Enumeration entry.
+type CWE struct {
+	CWEID *string `json:"cwe_id,omitempty"`
+	Name  *string `json:"name,omitempty"`
+}
+
+func (e *DependabotAlertEvent) GetAction() string {
+	if e == nil || e.Action == nil {
+		return ""
+	}
+	return *e.Action
+}
+
+func (e *DependabotAlertEvent) GetAlert() *DependabotAlert {
+	if e == nil {
+		return nil
+	}
+	return e.Alert
+}
+
+func (e *DependabotAlertEvent) GetRepo() *github.Repository {
+	if e == nil {
+		return nil
+	}
+	return e.Repo
+}
+
+func (e *DependabotAlertEvent) GetSender() *github.User {
+	if e == nil {
+		return nil
+	}
+	return e.Sender
+}
+
+func (a *DependabotAlert) GetNumber() int {
+	if a == nil || a.Number == nil {
+		return 0
+	}
+	return *a.Number
+}
+
+func (a *DependabotAlert) GetHTMLURL() string {
+	if a == nil || a.HTMLURL == nil {
+		return ""
+	}
+	return *a.HTMLURL
+}
+
+func (a *DependabotAlert) GetPackage() *github.VulnerabilityPackage {
+	if a == nil || a.Dependency == nil {
+		return nil
+	}
+	return a.Dependency.Package
+}
+
+func (a *DependabotAlert) GetManifestPath() string {
+	if a == nil || a.Dependency == nil || a.Dependency.ManifestPath == nil {
+		return ""
+	}
+	return *a.Dependency.ManifestPath
+}
+
+func (a *DependabotAlert) GetSecurityAdvisory() *SecurityAdvisory {
+	if a == nil {
+		return nil
+	}
+	return a.SecurityAdvisory
+}
+
+func (a *DependabotAlert) GetSecurityVulnerability() *github.AdvisoryVulnerability {
+	if a == nil {
+		return nil
+	}
+	return a.SecurityVulnerability
+}
+
+func (e *SecurityAdvisoryEvent) GetAction() string {
+	if e == nil || e.Action == nil {
+		return ""
+	}
+	return *e.Action
+}
+
+func (e *SecurityAdvisoryEvent) GetSecurityAdvisory() *SecurityAdvisory {
+	if e == nil {
+		return nil
+	}
+	return e.SecurityAdvisory
+}
+
+func (a *SecurityAdvisory) GetGHSAID() string {
+	if a == nil || a.GHSAID == nil {
+		return ""
+	}
+	return *a.GHSAID
+}
+
+func (a *SecurityAdvisory) GetCVEID() string {
+	if a == nil || a.CVEID == nil {
+		return ""
+	}
+	return *a.CVEID
+}
+
+func (a *SecurityAdvisory) GetSummary() string {
+	if a == nil || a.Summary == nil {
+		return ""
+	}
+	return *a.Summary
+}
+
+func (a *SecurityAdvisory) GetDescription() string {
+	if a == nil || a.Description == nil {
+		return ""
+	}
+	return *a.Description
+}
+
+func (a *SecurityAdvisory) GetSeverity() string {
+	if a == nil || a.Severity == nil {
+		return ""
+	}
+	return *a.Severity
+}
+
+func (a *SecurityAdvisory) GetCWEs() []*CWE {
+	if a == nil {
+		return nil
+	}
+	return a.CWEs
+}
+
+func (c *CWE) GetCWEID() string {
+	if c == nil || c.CWEID == nil {
+		return ""
+	}
+	return *c.CWEID
+}
+
+func (c *CWE) GetName() string {
+	if c == nil || c.Name == nil {
+		return ""
+	}
+	return *c.Name
+}
+
+// SponsorshipEvent is triggered when a sponsorship of the sponsored account is
+// created, cancelled or changed. go-github does not define it.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#sponsorship
+type SponsorshipEvent struct {
+	// Action is the action that was performed, e.g. "created", "cancelled",
+	// "edited" or "tier_changed".
+	Action      *string      `json:"action,omitempty"`
+	Sponsorship *Sponsorship `json:"sponsorship,omitempty"`
+	Sender      *github.User `json:"sender,omitempty"`
+}
+
+// Sponsorship is a GitHub Sponsors sponsorship.
+type Sponsorship struct {
+	Sponsorable *github.User `json:"sponsorable,omitempty"`
+	Sponsor     *github.User `json:"sponsor,omitempty"`
+	// PrivacyLevel is "public" or "private". The sponsor of private
+	// sponsorships must not be revealed.
+	PrivacyLevel *string `json:"privacy_level,omitempty"`
+	Tier         *struct {
+		Name      *string `json:"name,omitempty"`
+		IsOneTime *bool   `json:"is_one_time,omitempty"`
+	} `json:"tier,omitempty"`
+}
+
+func (e *SponsorshipEvent) GetAction() string {
+	if e == nil || e.Action == nil {
+		return ""
+	}
+	return *e.Action
+}
+
+func (e *SponsorshipEvent) GetSponsorship() *Sponsorship {
+	if e == nil {
+		return nil
+	}
+	return e.Sponsorship
+}
+
+func (e *SponsorshipEvent) GetSender() *github.User {
+	if e == nil {
+		return nil
+	}
+	return e.Sender
+}
+
+func (s *Sponsorship) GetSponsorable() *github.User {
+	if s == nil {
+		return nil
+	}
+	return s.Sponsorable
+}
+
+// GetSponsor returns the sponsor, or nil if the sponsorship is private.
+func (s *Sponsorship) GetSponsor() *github.User {
+	if s == nil || s.GetPrivate() {
+		return nil
+	}
+	return s.Sponsor
+}
+
+func (s *Sponsorship) GetPrivate() bool {
+	return s != nil && s.PrivacyLevel != nil && *s.PrivacyLevel == "private"
+}
+
+func (s *Sponsorship) GetTierName() string {
+	if s == nil || s.Tier == nil || s.Tier.Name == nil {
+		return ""
+	}
+	return *s.Tier.Name
+}
+
+func (s *Sponsorship) GetTierOneTime() bool {
+	return s != nil && s.Tier != nil && s.Tier.IsOneTime != nil && *s.Tier.IsOneTime
+}
+
+// CommitCommentEvent is triggered when a commit is commented on. It replaces
+// github.CommitCommentEvent, whose comments lack their line.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#commit_comment
+type CommitCommentEvent struct {
+	// Action is the action that was performed: "created".
+	Action       *string              `json:"action,omitempty"`
+	Comment      *CommitComment       `json:"comment,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+}
+
+// CommitComment is a comment on a commit, or on a line of a file of a commit.
+type CommitComment struct {
+	github.RepositoryComment
+	// Line is the line of Path commented on, if any.
+	Line *int `json:"line,omitempty"`
+}
+
+func (e *CommitCommentEvent) GetAction() string {
+	if e == nil || e.Action == nil {
+		return ""
+	}
+	return *e.Action
+}
+
+func (e *CommitCommentEvent) GetComment() *CommitComment {
+	if e == nil {
+		return nil
+	}
+	return e.Comment
+}
+
+func (e *CommitCommentEvent) GetRepo() *github.Repository {
+	if e == nil {
+		return nil
+	}
+	return e.Repo
+}
+
+func (e *CommitCommentEvent) GetSender() *github.User {
+	if e == nil {
+		return nil
+	}
+	return e.Sender
+}
+
+func (c *CommitComment) GetLine() int {
+	if c == nil || c.Line == nil {
+		return 0
+	}
+	return *c.Line
+}
+
+// ProjectsV2ItemEvent is triggered when an item of a project (of the new
+// Projects experience) is created, edited, archived or deleted. go-github does
+// not define it. Items only name their content by node ID, which must be
+// looked up with GraphQL.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#projects_v2_item
+type ProjectsV2ItemEvent struct {
+	// Action is the action that was performed, e.g. "created", "edited",
+	// "archived" or "deleted".
+	Action *string         `json:"action,omitempty"`
+	Item   *ProjectsV2Item `json:"projects_v2_item,omitempty"`
+	// Changes is only set for edited items.
+	Changes *struct {
+		FieldValue *struct {
+			FieldNodeID *string `json:"field_node_id,omitempty"`
+			FieldType   *string `json:"field_type,omitempty"`
+		} `json:"field_value,omitempty"`
+	} `json:"changes,omitempty"`
+	Org          *github.Organization `json:"organization,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+}
+
+// ProjectsV2Item is an item of a project.
+type ProjectsV2Item struct {
+	NodeID        *string `json:"node_id,omitempty"`
+	ProjectNodeID *string `json:"project_node_id,omitempty"`
+	ContentNodeID *string `json:"content_node_id,omitempty"`
+	// ContentType is "Issue", "PullRequest" or "DraftIssue".
+	ContentType *string `json:"content_type,omitempty"`
+}
+
+func (e *ProjectsV2ItemEvent) GetAction() string {
+	if e == nil || e.Action == nil {
+		return ""
+	}
+	return *e.Action
+}
+
+func (e *ProjectsV2ItemEvent) GetItem() *ProjectsV2Item {
+	if e == nil {
+		return nil
+	}
+	return e.Item
+}
+
+// GetChangedFieldNodeID returns the node ID of the field whose value was
+// changed, if any.
+func (e *ProjectsV2ItemEvent) GetChangedFieldNodeID() string {
+	if e == nil || e.Changes == nil || e.Changes.FieldValue == nil || e.Changes.FieldValue.FieldNodeID == nil {
+		return ""
+	}
+	return *e.Changes.FieldValue.FieldNodeID
+}
+
+func (e *ProjectsV2ItemEvent) GetSender() *github.User {
+	if e == nil {
+		return nil
+	}
+	return e.Sender
+}
+
+func (i *ProjectsV2Item) GetNodeID() string {
+	if i == nil || i.NodeID == nil {
+		return ""
+	}
+	return *i.NodeID
+}
+
+func (i *ProjectsV2Item) GetProjectNodeID() string {
+	if i == nil || i.ProjectNodeID == nil {
+		return ""
+	}
+	return *i.ProjectNodeID
+}
+
+func (i *ProjectsV2Item) GetContentNodeID() string {
+	if i == nil || i.ContentNodeID == nil {
+		return ""
+	}
+	return *i.ContentNodeID
+}
+
+func (i *ProjectsV2Item) GetContentType() string {
+	if i == nil || i.ContentType == nil {
+		return ""
+	}
+	return *i.ContentType
+}
+
+// ProjectCardEvent is triggered when a card of a classic project is created,
+// moved, converted or deleted. It extends github.ProjectCardEvent, whose
+// changes lack the column that cards were moved from.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#project_card
+type ProjectCardEvent struct {
+	github.ProjectCardEvent
+	Changes *struct {
+		ColumnID *struct {
+			From *int64 `json:"from,omitempty"`
+		} `json:"column_id,omitempty"`
+	} `json:"changes,omitempty"`
+}
+
+// GetColumnChanged returns whether the card was moved to another column.
+func (e *ProjectCardEvent) GetColumnChanged() bool {
+	return e != nil && e.Changes != nil && e.Changes.ColumnID != nil
+}
+
+// PullRequestEvent extends github.PullRequestEvent with the reason that auto
+// merge was disabled or the pull request was removed from the merge queue.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#pull_request
+type PullRequestEvent struct {
+	github.PullRequestEvent
+	// Reason is only set for the "auto_merge_disabled" and "dequeued"
+	// actions, e.g. "MERGE_CONFLICT".
+	Reason *string `json:"reason,omitempty"`
+}
+
+func (e *PullRequestEvent) GetReason() string {
+	if e == nil || e.Reason == nil {
+		return ""
+	}
+	return *e.Reason
+}
+
+// MergeGroupEvent is triggered when the merge queue requests checks of a merge
+// group, or destroys it. go-github does not define it.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#merge_group
+type MergeGroupEvent struct {
+	// Action is "checks_requested" or "destroyed".
+	Action *string `json:"action,omitempty"`
+	// Reason is why the merge group was destroyed: "merged", "invalidated"
+	// or "dequeued".
+	Reason       *string              `json:"reason,omitempty"`
+	MergeGroup   *MergeGroup          `json:"merge_group,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+}
+
+// MergeGroup is a group of pull requests tested together by the merge queue.
+type MergeGroup struct {
+	HeadSHA *string `json:"head_sha,omitempty"`
+	// HeadRef is the temporary branch of the merge group, e.g.
+	// "refs/heads/gh-readonly-queue/main/pr-12-<sha>".
+	HeadRef *string `json:"head_ref,omitempty"`
+	BaseRef *string `json:"base_ref,omitempty"`
+}
+
+func (e *MergeGroupEvent) GetAction() string {
+	if e == nil || e.Action == nil {
+		return ""
+	}
+	return *e.Action
+}
+
+func (e *MergeGroupEvent) GetReason() string {
+	if e == nil || e.Reason == nil {
+		return ""
+	}
+	return *e.Reason
+}
+
+func (e *MergeGroupEvent) GetMergeGroup() *MergeGroup {
+	if e == nil {
+		return nil
+	}
+	return e.MergeGroup
+}
+
+func (e *MergeGroupEvent) GetRepo() *github.Repository {
+	if e == nil {
+		return nil
+	}
+	return e.Repo
+}
+
+func (e *MergeGroupEvent) GetSender() *github.User {
+	if e == nil {
+		return nil
+	}
+	return e.Sender
+}
+
+func (g *MergeGroup) GetHeadSHA() string {
+	if g == nil || g.HeadSHA == nil {
+		return ""
+	}
+	return *g.HeadSHA
+}
+
+func (g *MergeGroup) GetHeadRef() string {
+	if g == nil || g.HeadRef == nil {
+		return ""
+	}
+	return *g.HeadRef
+}
+
+func (g *MergeGroup) GetBaseRef() string {
+	if g == nil || g.BaseRef == nil {
+		return ""
+	}
+	return *g.BaseRef
+}
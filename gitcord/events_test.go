@@ -0,0 +1,161 @@
+package gitcord
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+)
+
+func TestParsePayload(t *testing.T) {
+	type test struct {
+		name    string
+		evType  string
+		payload string
+		check   func(t *testing.T, data any)
+	}
+
+	tests := []test{
+		{
+			name:   "answered discussion",
+			evType: "DiscussionEvent",
+			payload: `{
+				"action": "answered",
+				"discussion": {
+					"number": 7,
+					"title": "Help",
+					"answer_html_url": "https://github.com/o/r/discussions/7#discussioncomment-42",
+					"answer_chosen_by": {"login": "octocat"},
+					"category": {"name": "Q&A", "is_answerable": true}
+				},
+				"answer": {"id": 42, "body": "Use v2."},
+				"repository": {"full_name": "o/r"}
+			}`,
+			check: func(t *testing.T, data any) {
+				ev := data.(*DiscussionEvent)
+				if got := ev.GetDiscussion().GetAnswerChosenBy().GetLogin(); got != "octocat" {
+					t.Errorf("unexpected answer chosen by %q", got)
+				}
+				if got := ev.GetAnswer().GetID(); got != 42 {
+					t.Errorf("unexpected answer %d", got)
+				}
+				if got := ev.GetDiscussion().GetAnswerHTMLURL(); got != "https://github.com/o/r/discussions/7#discussioncomment-42" {
+					t.Errorf("unexpected answer URL %q", got)
+				}
+			},
+		},
+		{
+			name:    "discussion comment reply",
+			evType:  "DiscussionCommentEvent",
+			payload: `{"action": "created", "comment": {"id": 43, "parent_id": 42}, "discussion": {"number": 7}, "repository": {"full_name": "o/r"}}`,
+			check: func(t *testing.T, data any) {
+				ev := data.(*DiscussionCommentEvent)
+				if ev.GetComment().GetParentID() != 42 || ev.GetDiscussion().GetNumber() != 7 || ev.GetRepo().GetFullName() != "o/r" {
+					t.Errorf("unexpected event %+v", ev)
+				}
+			},
+		},
+		{
+			name:   "dependabot alert",
+			evType: "DependabotAlertEvent",
+			payload: `{
+				"action": "created",
+				"alert": {
+					"number": 3,
+					"dependency": {"package": {"ecosystem": "go", "name": "golang.org/x/net"}, "manifest_path": "go.mod"},
+					"security_advisory": {"ghsa_id": "GHSA-xxxx", "cwes": [{"cwe_id": "CWE-400"}]},
+					"security_vulnerability": {"severity": "high", "first_patched_version": {"identifier": "0.7.0"}}
+				},
+				"repository": {"full_name": "o/r"}
+			}`,
+			check: func(t *testing.T, data any) {
+				alert := data.(*DependabotAlertEvent).GetAlert()
+				if got := alert.GetPackage().GetName(); got != "golang.org/x/net" {
+					t.Errorf("unexpected package %q", got)
+				}
+				if got := alert.GetSecurityAdvisory().GetCWEs()[0].GetCWEID(); got != "CWE-400" {
+					t.Errorf("unexpected CWE %q", got)
+				}
+				if got := alert.GetSecurityVulnerability().GetFirstPatchedVersion().GetIdentifier(); got != "0.7.0" {
+					t.Errorf("unexpected patched version %q", got)
+				}
+			},
+		},
+		{
+			name:    "commit comment on line",
+			evType:  "CommitCommentEvent",
+			payload: `{"action": "created", "comment": {"commit_id": "abc", "path": "main.go", "line": 12, "body": "typo"}, "repository": {"full_name": "o/r"}}`,
+			check: func(t *testing.T, data any) {
+				comment := data.(*CommitCommentEvent).GetComment()
+				if comment.GetPath() != "main.go" || comment.GetLine() != 12 || comment.GetBody() != "typo" {
+					t.Errorf("unexpected comment %+v", comment)
+				}
+			},
+		},
+		{
+			name:    "project card moved between columns",
+			evType:  "ProjectCardEvent",
+			payload: `{"action": "moved", "changes": {"column_id": {"from": 1}}, "project_card": {"column_id": 2, "content_url": "https://api.github.com/repos/o/r/issues/12"}, "repository": {"full_name": "o/r"}}`,
+			check: func(t *testing.T, data any) {
+				ev := data.(*ProjectCardEvent)
+				if !ev.GetColumnChanged() || ev.GetProjectCard().GetColumnID() != 2 || ev.GetRepo().GetFullName() != "o/r" {
+					t.Errorf("unexpected event %+v", ev)
+				}
+			},
+		},
+		{
+			name:    "dequeued pull request",
+			evType:  "PullRequestEvent",
+			payload: `{"action": "dequeued", "reason": "MERGE_CONFLICT", "pull_request": {"number": 12}, "repository": {"full_name": "o/r"}}`,
+			check: func(t *testing.T, data any) {
+				ev := data.(*PullRequestEvent)
+				if ev.GetPullRequest().GetNumber() != 12 || ev.GetReason() != "MERGE_CONFLICT" || ev.GetRepo().GetFullName() != "o/r" {
+					t.Errorf("unexpected event %+v", ev)
+				}
+			},
+		},
+		{
+			name:    "converted to draft",
+			evType:  "PullRequestEvent",
+			payload: `{"action": "converted_to_draft", "pull_request": {"number": 12, "draft": true}, "repository": {"full_name": "o/r"}}`,
+			check: func(t *testing.T, data any) {
+				ev := data.(*PullRequestEvent)
+				if !ev.GetPullRequest().GetDraft() || ev.GetAction() != "converted_to_draft" {
+					t.Errorf("unexpected event %+v", ev)
+				}
+			},
+		},
+		{
+			name:    "merge group",
+			evType:  "MergeGroupEvent",
+			payload: `{"action": "checks_requested", "merge_group": {"head_ref": "refs/heads/gh-readonly-queue/main/pr-12-2f4a6d8c"}, "repository": {"full_name": "o/r"}}`,
+			check: func(t *testing.T, data any) {
+				ev := data.(*MergeGroupEvent)
+				if got := ev.GetMergeGroup().GetHeadRef(); got != "refs/heads/gh-readonly-queue/main/pr-12-2f4a6d8c" {
+					t.Errorf("unexpected head ref %q", got)
+				}
+			},
+		},
+		{
+			name:    "go-github event",
+			evType:  "PushEvent",
+			payload: `{"ref": "refs/heads/main"}`,
+			check: func(t *testing.T, data any) {
+				if ev, ok := data.(*github.PushEvent); !ok || ev.GetRef() != "refs/heads/main" {
+					t.Errorf("unexpected payload %#v", data)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			raw := json.RawMessage(test.payload)
+			data, err := parsePayload(&github.Event{Type: &test.evType, RawPayload: &raw})
+			if err != nil {
+				t.Fatal("failed to parse payload:", err)
+			}
+			test.check(t, data)
+		})
+	}
+}
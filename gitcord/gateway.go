@@ -0,0 +1,72 @@
+package gitcord
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/session"
+)
+
+// intentMessageContent is the privileged intent required to read the content
+// of messages. It must also be enabled in the Discord developer portal.
+//
+// https://discord.com/developers/docs/topics/gateway#message-content-intent
+const intentMessageContent gateway.Intents = 1 << 15
+
+// Gateway keeps a connection to the Discord gateway open to react to activity
+// in the threads created by a Client. Messages sent in those threads are
+// posted to their issue or pull request as GitHub comments. If the Client is
+// configured with CommandPermissions, then slash commands acting on the
+// linked issue or pull request are registered as well.
+//
+// Threads are found through the Client's store, so a persistent store should
+// be used.
+type Gateway struct {
+	client  *Client
+	session *session.Session
+	ctx     context.Context
+}
+
+// NewGateway creates a new Gateway for c.
+func NewGateway(c *Client) *Gateway {
+	g := &Gateway{
+		client: c,
+		session: session.NewWithIntents(c.client.config.DiscordToken,
+			gateway.IntentGuilds,
+			gateway.IntentGuildMessages,
+			intentMessageContent,
+		),
+		ctx: context.Background(),
+	}
+
+	g.session.AddHandler(g.onMessageCreate)
+	g.session.AddInteractionHandler(g.newRouter())
+	return g
+}
+
+func (g *Gateway) logln(v ...any) {
+	prefixed := []any{"gateway:"}
+	prefixed = append(prefixed, v...)
+	g.client.client.config.Logger.Println(prefixed...)
+}
+
+// Run connects to the Discord gateway and blocks until ctx is done or the
+// connection fails.
+func (g *Gateway) Run(ctx context.Context) error {
+	g.ctx = ctx
+
+	if len(g.client.client.config.CommandPermissions) > 0 {
+		if err := g.registerCommands(); err != nil {
+			return err
+		}
+	}
+
+	return g.session.Connect(ctx)
+}
+
+func (g *Gateway) onMessageCreate(ev *gateway.MessageCreateEvent) {
+	c := g.client.WithContext(g.ctx)
+	if err := c.Replies.PostCommentMsg(&ev.Message); err != nil {
+		g.logln("failed to post message", ev.ID, "to GitHub:", err)
+	}
+}
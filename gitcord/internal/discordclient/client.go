@@ -6,11 +6,13 @@ import (
 	"log"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/ethanthatonekid/gitcord/gitcord/internal/slices"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
 	"github.com/pkg/errors"
 )
 
@@ -24,6 +26,18 @@ type Client struct {
 type Config struct {
 	Token     string
 	ChannelID discord.ChannelID
+	// Store maps issues and pull requests to their threads. By default, an
+	// in-memory store is used.
+	Store store.Store
+	// Mentions are the roles mentioned in every message sent by SendEmbeds.
+	Mentions []discord.RoleID
+	// ThreadKind is recorded in the store for the threads opened and found by
+	// the client.
+	ThreadKind store.ThreadKind
+	// SharedChannel returns true if the threads under the parent channel ch may
+	// belong to several repositories. Threads of those are only found by their
+	// "owner/repo#12:" name prefix. It is optional.
+	SharedChannel func(ch discord.ChannelID) bool
 	// Logger is optional. By default, it will log to the standard logger.
 	Logger *log.Logger
 }
@@ -34,6 +48,10 @@ func New(cfg Config) *Client {
 		cfg.Logger = log.Default()
 	}
 
+	if cfg.Store == nil {
+		cfg.Store = store.NewMemory()
+	}
+
 	return &Client{
 		Client: api.NewClient(cfg.Token),
 		config: cfg,
@@ -53,6 +71,64 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 	}
 }
 
+// ForChannel returns a copy of the client that opens and finds threads under
+// the given parent channel instead.
+func (c *Client) ForChannel(id discord.ChannelID) *Client {
+	cpy := *c
+	cpy.config.ChannelID = id
+	return &cpy
+}
+
+// WithThreadKind returns a copy of the client that records the threads it
+// opens and finds as the given kind.
+func (c *Client) WithThreadKind(kind store.ThreadKind) *Client {
+	cpy := *c
+	cpy.config.ThreadKind = kind
+	return &cpy
+}
+
+// WithMentions returns a copy of the client that also mentions the given roles
+// in every message it sends.
+func (c *Client) WithMentions(roles ...discord.RoleID) *Client {
+	cpy := *c
+	cpy.config.Mentions = append(append([]discord.RoleID(nil), c.config.Mentions...), roles...)
+	return &cpy
+}
+
+// SendEmbeds sends embeds into ch, mentioning the roles given to
+// WithMentions.
+func (c *Client) SendEmbeds(ch discord.ChannelID, embeds ...discord.Embed) (*discord.Message, error) {
+	if len(c.config.Mentions) == 0 {
+		return c.Client.SendEmbeds(ch, embeds...)
+	}
+
+	return c.SendMessageComplex(ch, api.SendMessageData{
+		Content:         c.mentionContent(),
+		Embeds:          embeds,
+		AllowedMentions: c.allowedMentions(),
+	})
+}
+
+func (c *Client) mentionContent() string {
+	mentions := make([]string, len(c.config.Mentions))
+	for i, role := range c.config.Mentions {
+		mentions[i] = role.Mention()
+	}
+	return strings.Join(mentions, " ")
+}
+
+func (c *Client) allowedMentions() *api.AllowedMentions {
+	if len(c.config.Mentions) == 0 {
+		return nil
+	}
+	return &api.AllowedMentions{Roles: c.config.Mentions}
+}
+
+// ChannelID returns the ID of the parent channel.
+func (c *Client) ChannelID() discord.ChannelID {
+	return c.config.ChannelID
+}
+
 func (c *Client) guildID() (discord.GuildID, error) {
 	ch, err := c.Channel(c.config.ChannelID)
 	if err != nil {
@@ -99,23 +175,37 @@ func (c *Client) threads() ([]discord.Channel, error) {
 	return threads, nil
 }
 
+// ErrThreadNotFound is returned when an issue or pull request has no thread.
+var ErrThreadNotFound = errors.New("thread not found")
+
 const (
 	totalRetries  = 10
 	retryWaitTime = 10 * time.Second
 )
 
-func (c *Client) FindThreadByNumber(id int) (*discord.Channel, error) {
-	for i := 0; i < totalRetries; i++ {
+// FindThreadByNumber scans the names of all threads under the parent channel
+// for one named after the given issue or pull request. It retries for a while
+// in case the thread is still being created.
+func (c *Client) FindThreadByNumber(k store.Key) (*discord.Channel, error) {
+	return c.findThreadByNumber(k, totalRetries)
+}
+
+func (c *Client) findThreadByNumber(k store.Key, retries int) (*discord.Channel, error) {
+	for i := 0; i < retries; i++ {
 		chs, err := c.threads()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get threads: %w", err)
 		}
 
-		ch := findChannelByNumber(chs, id)
+		ch := findChannelByNumber(chs, k, c.sharedChannel())
 		if ch != nil {
 			return ch, nil
 		}
 
+		if i == retries-1 {
+			break
+		}
+
 		ticker := time.NewTicker(retryWaitTime)
 		defer ticker.Stop()
 
@@ -127,14 +217,29 @@ func (c *Client) FindThreadByNumber(id int) (*discord.Channel, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("thread #%d not found", id)
+	return nil, fmt.Errorf("%w: %s", ErrThreadNotFound, k)
+}
+
+func (c *Client) sharedChannel() bool {
+	return c.config.SharedChannel != nil && c.config.SharedChannel(c.config.ChannelID)
 }
 
-func findChannelByNumber(channels []discord.Channel, targetID int) *discord.Channel {
+// findChannelByNumber finds the thread of k by its name. Threads in channels
+// shared by several repositories are named "owner/repo#12: title", otherwise
+// they are named "12: title". The latter could belong to any repository, so
+// they are not considered in shared channels.
+func findChannelByNumber(channels []discord.Channel, k store.Key, shared bool) *discord.Channel {
+	prefix := fmt.Sprintf("%s#%d:", k.Repo, k.Number)
+	if ch := slices.Find(channels, func(ch *discord.Channel) bool {
+		return strings.HasPrefix(ch.Name, prefix)
+	}); ch != nil || shared {
+		return ch
+	}
+
 	return slices.Find(channels, func(ch *discord.Channel) bool {
 		var n int
 		_, err := fmt.Sscanf(ch.Name, "%d", &n)
-		return err == nil && n == targetID
+		return err == nil && n == k.Number
 	})
 }
 
@@ -200,6 +305,29 @@ func (c *Client) FindMsgByPR(ch *discord.Channel, issueID int) *discord.Message
 	})
 }
 
+var DiscussionMsgPrefix = "Discussion opened: #"
+var discussionNumberRe = regexp.MustCompile(DiscussionMsgPrefix + `(\d+)`)
+
+func (c *Client) FindMsgByDiscussion(ch *discord.Channel, number int) *discord.Message {
+	return c.findMsg(ch, true, func(msg *discord.Message) bool {
+		if len(msg.Embeds) != 1 {
+			return false
+		}
+
+		matches := discussionNumberRe.FindStringSubmatch(msg.Embeds[0].Title)
+		if len(matches) != 2 {
+			return false
+		}
+
+		n, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return false
+		}
+
+		return n == number
+	})
+}
+
 func (c *Client) findMsg(ch *discord.Channel, fromTop bool, f func(msg *discord.Message) bool) *discord.Message {
 	var lastID discord.MessageID
 	msgs := make([]discord.Message, 0, 100)
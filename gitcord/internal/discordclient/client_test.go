@@ -0,0 +1,65 @@
+package discordclient
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+)
+
+func TestFindChannelByNumber(t *testing.T) {
+	type test struct {
+		name     string
+		channels []string
+		shared   bool
+		want     string
+	}
+
+	k := store.Key{Repo: "o/r", Number: 12}
+
+	tests := []test{
+		{
+			name:     "prefixed",
+			channels: []string{"o/other#12: Other", "o/r#12: Title"},
+			shared:   true,
+			want:     "o/r#12: Title",
+		},
+		{
+			name:     "bare number",
+			channels: []string{"11: Other", "12: Title"},
+			want:     "12: Title",
+		},
+		{
+			name:     "prefixed before bare number",
+			channels: []string{"12: Other", "o/r#12: Title"},
+			want:     "o/r#12: Title",
+		},
+		{
+			name:     "bare number in shared channel",
+			channels: []string{"12: Title"},
+			shared:   true,
+		},
+		{
+			name:     "other repository",
+			channels: []string{"o/other#12: Title"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			channels := make([]discord.Channel, len(test.channels))
+			for i, name := range test.channels {
+				channels[i] = discord.Channel{Name: name}
+			}
+
+			var got string
+			if ch := findChannelByNumber(channels, k, test.shared); ch != nil {
+				got = ch.Name
+			}
+
+			if got != test.want {
+				t.Errorf("found %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package discordclient
+
+import (
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxAvailableTags is the maximum number of tags a forum channel can have.
+	maxAvailableTags = 20
+	// maxAppliedTags is the maximum number of tags a forum post can have.
+	maxAppliedTags = 5
+	// maxTagName is the maximum length of a tag name.
+	maxTagName = 20
+)
+
+// OpenThreadData is the data for OpenThread.
+type OpenThreadData struct {
+	// Name is the name of the thread.
+	Name string
+	// Embed is the initial message of the thread.
+	Embed discord.Embed
+	// Tags are the names of the tags to apply to the thread if the parent
+	// channel is a forum channel. Tag names are matched case-insensitively.
+	Tags []string
+	// CreateTags creates forum tags in Tags that do not exist yet.
+	CreateTags bool
+}
+
+// OpenThread opens a new thread under the parent channel with the given
+// initial message. If the parent channel is a forum channel, then a forum post
+// is created instead of a public thread.
+func (c *Client) OpenThread(data OpenThreadData) (*discord.Channel, *discord.Message, error) {
+	parent, err := c.Channel(c.config.ChannelID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open thread")
+	}
+
+	if parent.Type == discord.GuildForum {
+		return c.openForumPost(parent, data)
+	}
+
+	t, err := c.StartThreadWithoutMessage(parent.ID, api.StartThreadData{
+		Name:                data.Name,
+		Type:                discord.GuildPublicThread,
+		AutoArchiveDuration: discord.SevenDaysArchive,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open thread")
+	}
+
+	msg, err := c.SendEmbeds(t.ID, data.Embed)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to send message")
+	}
+
+	return t, msg, nil
+}
+
+// https://discord.com/developers/docs/resources/channel#start-thread-in-forum-channel
+type startForumThreadData struct {
+	Name                string                  `json:"name"`
+	AutoArchiveDuration discord.ArchiveDuration `json:"auto_archive_duration,omitempty"`
+	AppliedTags         []discord.TagID         `json:"applied_tags,omitempty"`
+	Message             forumThreadMessage      `json:"message"`
+}
+
+type forumThreadMessage struct {
+	Content         string               `json:"content,omitempty"`
+	Embeds          []discord.Embed      `json:"embeds"`
+	AllowedMentions *api.AllowedMentions `json:"allowed_mentions,omitempty"`
+}
+
+func (c *Client) openForumPost(forum *discord.Channel, data OpenThreadData) (*discord.Channel, *discord.Message, error) {
+	tags, err := c.forumTags(forum, data.Tags, data.CreateTags)
+	if err != nil {
+		c.logln("failed to resolve forum tags:", err)
+	}
+
+	var t *discord.Channel
+	err = c.RequestJSON(
+		&t, "POST",
+		api.EndpointChannels+forum.ID.String()+"/threads",
+		httputil.WithJSONBody(startForumThreadData{
+			Name:                data.Name,
+			AutoArchiveDuration: discord.SevenDaysArchive,
+			AppliedTags:         tags,
+			Message: forumThreadMessage{
+				Content:         c.mentionContent(),
+				Embeds:          []discord.Embed{data.Embed},
+				AllowedMentions: c.allowedMentions(),
+			},
+		}),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open thread")
+	}
+
+	// The starter message of a forum post shares its ID with the post.
+	msg := &discord.Message{ID: discord.MessageID(t.ID), ChannelID: t.ID}
+	return t, msg, nil
+}
+
+// SetThreadTags replaces the tags applied to thread t with the given tag
+// names. It does nothing if t is not a forum post.
+func (c *Client) SetThreadTags(t *discord.Channel, names []string, create bool) error {
+	parent, err := c.Channel(t.ParentID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get parent channel")
+	}
+
+	if parent.Type != discord.GuildForum {
+		return nil
+	}
+
+	tags, err := c.forumTags(parent, names, create)
+	if err != nil {
+		return err
+	}
+
+	return c.ModifyChannel(t.ID, api.ModifyChannelData{AppliedTags: &tags})
+}
+
+// forumTags resolves the given tag names to the IDs of the tags in forum. If
+// create is true, then missing tags are created as long as there is room for
+// them. Tags that cannot be resolved are skipped.
+func (c *Client) forumTags(forum *discord.Channel, names []string, create bool) ([]discord.TagID, error) {
+	find := func(name string) discord.TagID {
+		for _, tag := range forum.AvailableTags {
+			if strings.EqualFold(tag.Name, name) {
+				return tag.ID
+			}
+		}
+		return 0
+	}
+
+	var missing []string
+	for _, name := range names {
+		name = tagName(name)
+		if !find(name).IsValid() {
+			missing = append(missing, name)
+		}
+	}
+
+	if create && len(missing) > 0 && len(forum.AvailableTags) < maxAvailableTags {
+		available := append([]discord.Tag(nil), forum.AvailableTags...)
+		for _, name := range missing {
+			if len(available) == maxAvailableTags {
+				break
+			}
+			available = append(available, discord.Tag{Name: name})
+		}
+
+		err := c.ModifyChannel(forum.ID, api.ModifyChannelData{AvailableTags: &available})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create forum tags")
+		}
+
+		// Reload the forum to learn the IDs of the new tags.
+		forum, err = c.Channel(forum.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to reload forum channel")
+		}
+	}
+
+	ids := make([]discord.TagID, 0, len(names))
+	for _, name := range names {
+		if len(ids) == maxAppliedTags {
+			break
+		}
+		if id := find(tagName(name)); id.IsValid() && !containsTag(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// tagName truncates name to the maximum length of a tag name.
+func tagName(name string) string {
+	if r := []rune(name); len(r) > maxTagName {
+		return string(r[:maxTagName])
+	}
+	return name
+}
+
+func containsTag(ids []discord.TagID, id discord.TagID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package discordclient
+
+import (
+	"net/http"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
+	"github.com/pkg/errors"
+)
+
+// FindThread finds the thread of the given issue or pull request. The store is
+// consulted first. Threads that predate the store are found by scanning the
+// thread names, after which they are recorded in the store.
+func (c *Client) FindThread(k store.Key) (*discord.Channel, error) {
+	return c.findThread(k, totalRetries)
+}
+
+// ExistingThread is like FindThread, except it does not wait for a thread that
+// may still be in the process of being created.
+func (c *Client) ExistingThread(k store.Key) (*discord.Channel, error) {
+	return c.findThread(k, 1)
+}
+
+func (c *Client) findThread(k store.Key, retries int) (*discord.Channel, error) {
+	t, err := c.config.Store.Thread(k)
+	switch {
+	case err == nil:
+		ch, err := c.Channel(t.ChannelID)
+		if err == nil {
+			return ch, nil
+		}
+
+		if !isNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get thread of %s", k)
+		}
+
+		// The thread was deleted from under us, so forget about it.
+		c.logln("forgetting deleted thread", t.ChannelID, "of", k)
+		if err := c.config.Store.DeleteThread(k); err != nil {
+			return nil, errors.Wrapf(err, "failed to forget thread of %s", k)
+		}
+
+	case errors.Is(err, store.ErrNotFound):
+		// Fall back to scanning.
+
+	default:
+		return nil, errors.Wrapf(err, "failed to look up thread of %s", k)
+	}
+
+	ch, err := c.findThreadByNumber(k, retries)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.config.Store.SetThread(k, store.Thread{ChannelID: ch.ID, Kind: c.config.ThreadKind}); err != nil {
+		c.logln("failed to record thread of", k.String()+":", err)
+	}
+
+	return ch, nil
+}
+
+// RecordThread records the thread and initial message of the given issue or
+// pull request.
+func (c *Client) RecordThread(k store.Key, ch discord.ChannelID, msg discord.MessageID) error {
+	return c.config.Store.SetThread(k, store.Thread{ChannelID: ch, MessageID: msg, Kind: c.config.ThreadKind})
+}
+
+// FindInitialMsg finds the initial message in the thread ch of the given issue
+// or pull request. If the store does not know the initial message, scan is
+// used to find it within the thread, after which it is recorded in the store.
+func (c *Client) FindInitialMsg(k store.Key, ch *discord.Channel, scan func(ch *discord.Channel, n int) *discord.Message) *discord.Message {
+	t, err := c.config.Store.Thread(k)
+	if err == nil && t.ChannelID == ch.ID && t.MessageID.IsValid() {
+		return &discord.Message{ID: t.MessageID, ChannelID: ch.ID}
+	}
+
+	msg := scan(ch, k.Number)
+	if msg == nil {
+		return nil
+	}
+
+	if err := c.RecordThread(k, ch.ID, msg.ID); err != nil {
+		c.logln("failed to record initial message of", k.String()+":", err)
+	}
+
+	return msg
+}
+
+func isNotFound(err error) bool {
+	var httpErr *httputil.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound
+}
+
+// SendEmbedsFor sends embeds mirroring the GitHub object k into ch and records
+// the sent message in the store.
+func (c *Client) SendEmbedsFor(k store.MessageKey, ch discord.ChannelID, embeds ...discord.Embed) (*discord.Message, error) {
+	msg, err := c.SendEmbeds(ch, embeds...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.recordMsg(k, msg)
+	return msg, nil
+}
+
+// ReplyEmbedsFor is like SendEmbedsFor, except that the embeds are sent as a
+// reply to the message ref within ch.
+func (c *Client) ReplyEmbedsFor(k store.MessageKey, ch discord.ChannelID, ref discord.MessageID, embeds ...discord.Embed) (*discord.Message, error) {
+	msg, err := c.SendMessageComplex(ch, api.SendMessageData{
+		Content:         c.mentionContent(),
+		Embeds:          embeds,
+		Reference:       &discord.MessageReference{MessageID: ref},
+		AllowedMentions: c.allowedMentions(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.recordMsg(k, msg)
+	return msg, nil
+}
+
+func (c *Client) recordMsg(k store.MessageKey, msg *discord.Message) {
+	if err := c.config.Store.SetMessage(k, store.Message{ChannelID: msg.ChannelID, MessageID: msg.ID}); err != nil {
+		c.logln("failed to record message of", k.String()+":", err)
+	}
+}
+
+// FindMsg finds the message mirroring the GitHub object k within ch. Messages
+// that predate the store are found by the object ID in their embed footer,
+// after which they are recorded in the store.
+func (c *Client) FindMsg(k store.MessageKey, ch *discord.Channel) *discord.Message {
+	m, err := c.config.Store.Message(k)
+	if err == nil && m.ChannelID == ch.ID {
+		return &discord.Message{ID: m.MessageID, ChannelID: m.ChannelID}
+	}
+
+	msg := c.FindMsgByComment(ch, k.ID)
+	if msg == nil {
+		return nil
+	}
+
+	if err := c.config.Store.SetMessage(k, store.Message{ChannelID: ch.ID, MessageID: msg.ID}); err != nil {
+		c.logln("failed to record message of", k.String()+":", err)
+	}
+
+	return msg
+}
@@ -0,0 +1,284 @@
+package githubclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// AppConfig is the configuration for authenticating as a GitHub App.
+//
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/about-authentication-with-a-github-app
+type AppConfig struct {
+	// AppID is the ID of the GitHub App.
+	AppID int64
+	// PrivateKey is the PEM-encoded private key of the GitHub App.
+	PrivateKey []byte
+	// InstallationID is the ID of the installation to authenticate as. If
+	// zero, the installation is discovered per repository by ForRepo and
+	// ForPayload.
+	InstallationID int64
+}
+
+// jwtLifetime is how long app JWTs are valid for. GitHub allows at most 10
+// minutes.
+const jwtLifetime = 9 * time.Minute
+
+// jwtClockSkew backdates the issue time of app JWTs to allow for clock drift
+// between us and GitHub.
+const jwtClockSkew = time.Minute
+
+// app authenticates as a GitHub App and mints installation tokens. It is
+// shared between all copies of a Client.
+type app struct {
+	config AppConfig
+	// client is authenticated as the app itself, which may only be used to
+	// manage installations.
+	client *github.Client
+
+	mu            sync.Mutex
+	installations map[int64]*github.Client
+	repos         map[string]int64
+}
+
+func newApp(cfg AppConfig) *app {
+	src := &jwtTokenSource{appID: cfg.AppID}
+	src.key, src.err = parsePrivateKey(cfg.PrivateKey)
+
+	return &app{
+		config:        cfg,
+		client:        github.NewClient(oauth2.NewClient(context.Background(), oauth2.ReuseTokenSource(nil, src))),
+		installations: make(map[int64]*github.Client),
+		repos:         make(map[string]int64),
+	}
+}
+
+// installationClient returns a client authenticated as the installation id.
+// Clients are cached so that installation tokens are reused until they
+// expire.
+func (a *app) installationClient(id int64) *github.Client {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if client, ok := a.installations[id]; ok {
+		return client
+	}
+
+	src := oauth2.ReuseTokenSource(nil, &installationTokenSource{app: a.client, id: id})
+	client := github.NewClient(oauth2.NewClient(context.Background(), src))
+	a.installations[id] = client
+	return client
+}
+
+// repoInstallation returns the ID of the installation of the app on the
+// repository owner/repo.
+func (a *app) repoInstallation(ctx context.Context, owner, repo string) (int64, error) {
+	fullName := owner + "/" + repo
+
+	a.mu.Lock()
+	id, ok := a.repos[fullName]
+	a.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	installation, _, err := a.client.Apps.FindRepositoryInstallation(ctx, owner, repo)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find GitHub App installation on %s", fullName)
+	}
+
+	a.mu.Lock()
+	a.repos[fullName] = installation.GetID()
+	a.mu.Unlock()
+
+	return installation.GetID(), nil
+}
+
+// ForInstallation returns a copy of the client authenticated as the given
+// installation of the GitHub App. It returns c if the client does not
+// authenticate as a GitHub App.
+func (c *Client) ForInstallation(id int64) *Client {
+	if c.app == nil || id == c.installationID {
+		return c
+	}
+
+	cpy := *c
+	cpy.Client = c.app.installationClient(id)
+	cpy.installationID = id
+	return &cpy
+}
+
+// ForRepo returns a copy of the client authenticated as the installation of
+// the GitHub App on the repository owner/repo. It returns c if the client
+// does not authenticate as a GitHub App or is already bound to an
+// installation.
+func (c *Client) ForRepo(owner, repo string) (*Client, error) {
+	if c.app == nil || c.installationID != 0 {
+		return c, nil
+	}
+
+	id, err := c.app.repoInstallation(c.ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ForInstallation(id), nil
+}
+
+// ForPayload returns a copy of the client authenticated as the installation
+// of the GitHub App that the webhook payload was delivered for. Payloads
+// delivered to a GitHub App name their installation; otherwise the
+// installation is looked up from the payload's repository.
+func (c *Client) ForPayload(payload json.RawMessage) (*Client, error) {
+	if c.app == nil || c.installationID != 0 {
+		return c, nil
+	}
+
+	var pl struct {
+		Installation *struct {
+			ID int64 `json:"id"`
+		} `json:"installation"`
+		Repository *struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(payload, &pl); err != nil {
+		return nil, errors.Wrap(err, "failed to parse payload")
+	}
+
+	if pl.Installation != nil && pl.Installation.ID != 0 {
+		return c.ForInstallation(pl.Installation.ID), nil
+	}
+
+	if pl.Repository == nil {
+		return nil, errors.New("payload has neither an installation nor a repository")
+	}
+
+	owner, repo, ok := strings.Cut(pl.Repository.FullName, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository %q", pl.Repository.FullName)
+	}
+
+	return c.ForRepo(owner, repo)
+}
+
+// installationTokenSource mints installation access tokens, which expire after
+// an hour.
+//
+// https://docs.github.com/en/rest/apps/apps#create-an-installation-access-token-for-an-app
+type installationTokenSource struct {
+	app *github.Client
+	id  int64
+}
+
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	token, _, err := s.app.Apps.CreateInstallationToken(context.Background(), s.id, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create token for installation %d", s.id)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		TokenType:   "token",
+		Expiry:      token.GetExpiresAt(),
+	}, nil
+}
+
+// jwtTokenSource signs the JWTs used to authenticate as the GitHub App itself.
+//
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/generating-a-json-web-token-jwt-for-a-github-app
+type jwtTokenSource struct {
+	appID int64
+	key   *rsa.PrivateKey
+	err   error // error parsing the private key
+}
+
+func (s *jwtTokenSource) Token() (*oauth2.Token, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	now := time.Now()
+	jwt, err := signAppJWT(s.appID, s.key, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: jwt,
+		TokenType:   "Bearer",
+		Expiry:      now.Add(jwtLifetime),
+	}, nil
+}
+
+// signAppJWT returns a JWT for the app signed with RS256 and issued at now.
+func signAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign GitHub App JWT")
+	}
+
+	return signed + "." + enc.EncodeToString(sig), nil
+}
+
+// parsePrivateKey parses a PEM-encoded RSA private key. GitHub issues keys in
+// PKCS #1 form, but PKCS #8 is accepted as well.
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not PEM-encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse GitHub App private key")
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key is not an RSA key")
+	}
+
+	return rsaKey, nil
+}
@@ -0,0 +1,90 @@
+package githubclient
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignAppJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pems := map[string][]byte{
+		"PKCS1": pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		"PKCS8": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+	}
+
+	for name, data := range pems {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parsePrivateKey(data)
+			if err != nil {
+				t.Fatal("failed to parse key:", err)
+			}
+
+			now := time.Unix(1_700_000_000, 0)
+			jwt, err := signAppJWT(1234, parsed, now)
+			if err != nil {
+				t.Fatal("failed to sign JWT:", err)
+			}
+
+			parts := strings.Split(jwt, ".")
+			if len(parts) != 3 {
+				t.Fatalf("JWT has %d parts, want 3", len(parts))
+			}
+
+			sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+			if err != nil {
+				t.Fatal("failed to decode signature:", err)
+			}
+
+			sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, sum[:], sig); err != nil {
+				t.Fatal("invalid signature:", err)
+			}
+
+			claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+			if err != nil {
+				t.Fatal("failed to decode claims:", err)
+			}
+
+			var claims struct {
+				IAT int64  `json:"iat"`
+				EXP int64  `json:"exp"`
+				ISS string `json:"iss"`
+			}
+			if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+				t.Fatal("failed to parse claims:", err)
+			}
+
+			if claims.ISS != "1234" {
+				t.Errorf("unexpected iss %q", claims.ISS)
+			}
+			if claims.IAT != now.Add(-jwtClockSkew).Unix() {
+				t.Errorf("unexpected iat %d", claims.IAT)
+			}
+			if claims.EXP != now.Add(jwtLifetime).Unix() {
+				t.Errorf("unexpected exp %d", claims.EXP)
+			}
+		})
+	}
+
+	if _, err := parsePrivateKey([]byte("not a key")); err == nil {
+		t.Error("expected error parsing invalid key")
+	}
+}
@@ -2,6 +2,7 @@ package githubclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -15,6 +16,9 @@ type Client struct {
 	*github.Client
 	config Config
 	ctx    context.Context
+
+	app            *app  // nil unless authenticating as a GitHub App
+	installationID int64 // GitHub App installation the client is bound to
 }
 
 func (c *Client) WithContext(ctx context.Context) *Client {
@@ -23,20 +27,27 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 	return &cpy
 }
 
+// Context returns the context used for requests.
+func (c *Client) Context() context.Context {
+	return c.ctx
+}
+
 type Config struct {
-	OAuth  oauth2.TokenSource
+	OAuth oauth2.TokenSource
+	// App authenticates as a GitHub App installation instead of with OAuth.
+	App *AppConfig
+	// Repo is the owner/repo repository that EventByID fetches events from.
 	Repo   string
 	Logger *log.Logger // default log.Default()
 }
 
 // SplitGitHubRepo splits the GitHub repository path into its owner and name.
-func (c *Config) SplitGitHubRepo() (owner, repo string) {
-	var ok bool
-	owner, repo, ok = strings.Cut(c.Repo, "/")
+func (c *Config) SplitGitHubRepo() (owner, repo string, err error) {
+	owner, repo, ok := strings.Cut(c.Repo, "/")
 	if !ok {
-		panic("invalid GitHubRepo, must be in owner/repo form")
+		return "", "", fmt.Errorf("invalid GitHub repository %q, must be in owner/repo form", c.Repo)
 	}
-	return
+	return owner, repo, nil
 }
 
 func New(cfg Config) *Client {
@@ -44,17 +55,79 @@ func New(cfg Config) *Client {
 		cfg.Logger = log.Default()
 	}
 
+	if cfg.App != nil {
+		c := &Client{
+			config: cfg,
+			ctx:    context.Background(),
+			app:    newApp(*cfg.App),
+		}
+		// Until it is bound to an installation, the client authenticates as
+		// the app itself.
+		c.Client = c.app.client
+		return c.ForInstallation(cfg.App.InstallationID)
+	}
+
 	return &Client{
 		Client: github.NewClient(oauth2.NewClient(context.Background(), cfg.OAuth)),
 		config: cfg,
+		ctx:    context.Background(),
+	}
+}
+
+// Token returns the access token that the client authenticates with, e.g. to
+// authenticate Git operations.
+func (c *Client) Token() (*oauth2.Token, error) {
+	transport, ok := c.Client.Client().Transport.(*oauth2.Transport)
+	if !ok {
+		return nil, fmt.Errorf("client is not authenticated with OAuth")
+	}
+	return transport.Source.Token()
+}
+
+// GraphQL runs a GraphQL query with the given variables and decodes its data
+// into v.
+//
+// https://docs.github.com/en/graphql/guides/forming-calls-with-graphql
+func (c *Client) GraphQL(query string, vars map[string]any, v any) error {
+	req, err := c.NewRequest("POST", "graphql", map[string]any{
+		"query":     query,
+		"variables": vars,
+	})
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if _, err := c.Do(c.ctx, req, &resp); err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", resp.Errors[0].Message)
 	}
+
+	return json.Unmarshal(resp.Data, v)
 }
 
 func (c *Client) EventByID(eventID int64) (*github.Event, error) {
-	owner, repo := c.config.SplitGitHubRepo()
+	owner, repo, err := c.config.SplitGitHubRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err = c.ForRepo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
 	eventIDStr := fmt.Sprintf("%d", eventID)
 
-	var err error
 	var resp *github.Response
 	var evs []*github.Event
 	var nextPage int
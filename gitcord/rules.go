@@ -0,0 +1,195 @@
+package gitcord
+
+import (
+	"path"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/google/go-github/v47/github"
+)
+
+// Rule decides what happens to the events it matches. Rules are evaluated in
+// order before an event is handled, and the first rule matching an event
+// decides its fate. Events that no rule matches are handled as usual.
+type Rule struct {
+	// Match selects the events that the rule applies to.
+	Match RuleMatch `json:"match,omitempty"`
+	// Drop drops matching events without handling them.
+	Drop bool `json:"drop,omitempty"`
+	// ChannelID routes matching events into this parent channel instead of
+	// the one chosen by Routes, if set. Security alerts and advisories are
+	// only routed by rules that list their event type in Match.Events.
+	ChannelID discord.ChannelID `json:"channel_id,omitempty"`
+	// Mentions are the roles mentioned in the messages of matching events.
+	Mentions []discord.RoleID `json:"mentions,omitempty"`
+}
+
+// RuleMatch selects events. Every non-empty field must match for an event to
+// match. Fields holding a list match if any of their elements match.
+type RuleMatch struct {
+	// Events are event types, either as webhook event names like
+	// "pull_request" or as event types like "PullRequestEvent".
+	Events []string `json:"events,omitempty"`
+	// Actions are event actions like "labeled" or "opened".
+	Actions []string `json:"actions,omitempty"`
+	// Repos are owner/repo patterns in the syntax of path.Match. Patterns are
+	// matched case-insensitively, like GitHub compares repository names.
+	Repos []string `json:"repos,omitempty"`
+	// Labels are the names of labels, one of which the issue, pull request
+	// or discussion must have. Names are compared case-insensitively.
+	Labels []string `json:"labels,omitempty"`
+	// Authors are the logins of the users who opened the issue, pull
+	// request or discussion.
+	Authors []string `json:"authors,omitempty"`
+	// Bot matches events whose sender is or is not a bot.
+	Bot *bool `json:"bot,omitempty"`
+	// Draft matches pull requests that are or are not drafts. It never
+	// matches events not about a pull request.
+	Draft *bool `json:"draft,omitempty"`
+	// BaseBranches are patterns in the syntax of path.Match that the base
+	// branch of the pull request must match. They never match events not
+	// about a pull request.
+	BaseBranches []string `json:"base_branches,omitempty"`
+}
+
+// Rules is a list of rules. Refer to Rule for more information.
+type Rules []Rule
+
+// Find returns the first rule matching the event, or nil if none do.
+func (r Rules) Find(evType string, data any) *Rule {
+	if len(r) == 0 {
+		return nil
+	}
+
+	facts := newEventFacts(evType, data)
+	for i := range r {
+		if r[i].Match.matches(facts) {
+			return &r[i]
+		}
+	}
+
+	return nil
+}
+
+// sensitiveEvents are the event types whose messages must stay in their own
+// channel unless a rule explicitly routes them elsewhere.
+var sensitiveEvents = map[string]bool{
+	"DependabotAlertEvent":     true,
+	"CodeScanningAlertEvent":   true,
+	"SecretScanningAlertEvent": true,
+	"SecurityAdvisoryEvent":    true,
+}
+
+// channelFor returns the channel that the rule routes events of evType into,
+// if any. Sensitive events are only routed by rules naming their type, so that
+// broad rules don't leak them into public channels.
+func (r *Rule) channelFor(evType string) discord.ChannelID {
+	if sensitiveEvents[evType] && !anyOf(r.Match.Events, func(ev string) bool {
+		return ev == evType || EventType(ev) == evType
+	}) {
+		return 0
+	}
+	return r.ChannelID
+}
+
+// eventFacts are the properties of an event that rules match on.
+type eventFacts struct {
+	evType string
+	action string
+	repo   string
+	labels []string
+	author string
+	bot    bool
+	// pr is the pull request that the event is about, if any.
+	pr *github.PullRequest
+}
+
+func newEventFacts(evType string, data any) eventFacts {
+	facts := eventFacts{evType: evType}
+
+	if v, ok := data.(interface{ GetAction() string }); ok {
+		facts.action = v.GetAction()
+	}
+
+	facts.repo, _ = eventRepoName(data)
+
+	if v, ok := data.(interface{ GetSender() *github.User }); ok {
+		facts.bot = v.GetSender().GetType() == "Bot"
+	}
+
+	if v, ok := data.(interface{ GetPullRequest() *github.PullRequest }); ok && v.GetPullRequest() != nil {
+		facts.pr = v.GetPullRequest()
+		facts.author = facts.pr.GetUser().GetLogin()
+		facts.labels = labelNames(facts.pr.Labels)
+	} else if v, ok := data.(interface{ GetIssue() *github.Issue }); ok && v.GetIssue() != nil {
+		facts.author = v.GetIssue().GetUser().GetLogin()
+		facts.labels = labelNames(v.GetIssue().Labels)
+	} else if v, ok := data.(interface{ GetDiscussion() *Discussion }); ok && v.GetDiscussion() != nil {
+		facts.author = v.GetDiscussion().GetUser().GetLogin()
+		facts.labels = labelNames(v.GetDiscussion().Labels)
+	}
+
+	return facts
+}
+
+func (m *RuleMatch) matches(facts eventFacts) bool {
+	if len(m.Events) > 0 && !anyOf(m.Events, func(ev string) bool {
+		if !strings.HasSuffix(ev, "Event") {
+			ev = EventType(ev)
+		}
+		return ev == facts.evType
+	}) {
+		return false
+	}
+
+	if len(m.Actions) > 0 && !anyOf(m.Actions, func(action string) bool {
+		return action == facts.action
+	}) {
+		return false
+	}
+
+	if len(m.Repos) > 0 && !anyOf(m.Repos, func(pattern string) bool {
+		ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(facts.repo))
+		return ok
+	}) {
+		return false
+	}
+
+	if len(m.Labels) > 0 && !anyOf(m.Labels, func(label string) bool {
+		return anyOf(facts.labels, func(l string) bool { return strings.EqualFold(l, label) })
+	}) {
+		return false
+	}
+
+	if len(m.Authors) > 0 && !anyOf(m.Authors, func(author string) bool {
+		return strings.EqualFold(author, facts.author)
+	}) {
+		return false
+	}
+
+	if m.Bot != nil && *m.Bot != facts.bot {
+		return false
+	}
+
+	if m.Draft != nil && (facts.pr == nil || *m.Draft != facts.pr.GetDraft()) {
+		return false
+	}
+
+	if len(m.BaseBranches) > 0 && (facts.pr == nil || !anyOf(m.BaseBranches, func(pattern string) bool {
+		ok, _ := path.Match(pattern, facts.pr.GetBase().GetRef())
+		return ok
+	})) {
+		return false
+	}
+
+	return true
+}
+
+func anyOf(values []string, f func(string) bool) bool {
+	for _, v := range values {
+		if f(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package gitcord
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+)
+
+func TestRules(t *testing.T) {
+	const rulesJSON = `[
+		{"match": {"events": ["issues", "pull_request"], "actions": ["labeled", "unlabeled"], "bot": true}, "drop": true},
+		{"match": {"repos": ["ethanthatonekid/*"], "labels": ["Security"]}, "channel_id": "2", "mentions": ["3"]},
+		{"match": {"events": ["PullRequestEvent"], "draft": true}, "drop": true},
+		{"match": {"base_branches": ["release/*"], "authors": ["EthanThatOneKid"]}, "mentions": ["4"]}
+	]`
+
+	var rules Rules
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		t.Fatal("failed to parse rules:", err)
+	}
+
+	repo := &github.Repository{FullName: github.String("EthanThatOneKid/gitcord")}
+	bot := &github.User{Login: github.String("dependabot[bot]"), Type: github.String("Bot")}
+	human := &github.User{Login: github.String("ethanthatonekid"), Type: github.String("User")}
+	security := []*github.Label{{Name: github.String("security")}}
+
+	type test struct {
+		name   string
+		evType string
+		data   any
+		rule   int // index of the expected rule, or -1
+	}
+
+	tests := []test{
+		{
+			name:   "bot label churn",
+			evType: "IssuesEvent",
+			data:   &github.IssuesEvent{Action: github.String("labeled"), Repo: repo, Sender: bot, Issue: &github.Issue{}},
+			rule:   0,
+		},
+		{
+			name:   "human labeling",
+			evType: "IssuesEvent",
+			data:   &github.IssuesEvent{Action: github.String("labeled"), Repo: repo, Sender: human, Issue: &github.Issue{}},
+			rule:   -1,
+		},
+		{
+			name:   "security label",
+			evType: "IssueCommentEvent",
+			data:   &github.IssueCommentEvent{Action: github.String("created"), Repo: repo, Sender: bot, Issue: &github.Issue{Labels: security}},
+			rule:   1,
+		},
+		{
+			name:   "draft pull request",
+			evType: "PullRequestEvent",
+			data:   &github.PullRequestEvent{Action: github.String("opened"), Repo: repo, Sender: human, PullRequest: &github.PullRequest{Draft: github.Bool(true)}},
+			rule:   2,
+		},
+		{
+			name:   "draft match on issue",
+			evType: "PullRequestEvent",
+			data:   &github.IssuesEvent{Action: github.String("opened"), Repo: repo, Sender: human, Issue: &github.Issue{}},
+			rule:   -1,
+		},
+		{
+			name:   "release branch",
+			evType: "PullRequestReviewEvent",
+			data: &github.PullRequestReviewEvent{Action: github.String("submitted"), Repo: repo, Sender: human, PullRequest: &github.PullRequest{
+				User: human,
+				Base: &github.PullRequestBranch{Ref: github.String("release/v1")},
+			}},
+			rule: 3,
+		},
+		{
+			name:   "main branch",
+			evType: "PullRequestReviewEvent",
+			data: &github.PullRequestReviewEvent{Action: github.String("submitted"), Repo: repo, Sender: human, PullRequest: &github.PullRequest{
+				User: human,
+				Base: &github.PullRequestBranch{Ref: github.String("main")},
+			}},
+			rule: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule := rules.Find(test.evType, test.data)
+			switch {
+			case test.rule == -1 && rule != nil:
+				t.Errorf("unexpected match of rule %+v", *rule)
+			case test.rule != -1 && rule != &rules[test.rule]:
+				t.Errorf("expected match of rule %d, got %+v", test.rule, rule)
+			}
+		})
+	}
+
+	if ch := rules[1].channelFor("DependabotAlertEvent"); ch.IsValid() {
+		t.Errorf("broad rule routes security alerts into %d", ch)
+	}
+	explicit := Rule{Match: RuleMatch{Events: []string{"dependabot_alert"}}, ChannelID: 5}
+	if ch := explicit.channelFor("DependabotAlertEvent"); ch != 5 {
+		t.Errorf("explicit rule routes security alerts into %d", ch)
+	}
+
+	if rules[1].ChannelID != 2 || len(rules[1].Mentions) != 1 || rules[1].Mentions[0] != 3 {
+		t.Errorf("unexpected parsed rule %+v", rules[1])
+	}
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	threadsBucket    = []byte("threads")
+	threadKeysBucket = []byte("thread_keys") // thread channel ID -> key
+	messagesBucket   = []byte("messages")
+)
+
+// Bolt is a Store backed by an embedded bbolt key-value database.
+type Bolt struct {
+	db *bolt.DB
+}
+
+var _ Store = (*Bolt)(nil)
+
+// NewBolt opens or creates the bbolt database at path. The database is locked
+// for as long as it is open, so Close must be called when done.
+func NewBolt(path string) (*Bolt, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open database %q", path)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{threadsBucket, threadKeysBucket, messagesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "failed to initialize database")
+	}
+
+	return &Bolt{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Bolt) Close() error {
+	return s.db.Close()
+}
+
+func (s *Bolt) Thread(k Key) (Thread, error) {
+	var t Thread
+	return t, s.get(threadsBucket, k.String(), &t)
+}
+
+func (s *Bolt) SetThread(k Key, t Thread) error {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := deleteThreadKey(tx, k); err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(threadsBucket).Put([]byte(k.String()), b); err != nil {
+			return err
+		}
+
+		return tx.Bucket(threadKeysBucket).Put([]byte(t.ChannelID.String()), []byte(k.String()))
+	})
+}
+
+func (s *Bolt) DeleteThread(k Key) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := deleteThreadKey(tx, k); err != nil {
+			return err
+		}
+		return tx.Bucket(threadsBucket).Delete([]byte(k.String()))
+	})
+}
+
+// deleteThreadKey deletes the reverse mapping of the current thread of k.
+func deleteThreadKey(tx *bolt.Tx, k Key) error {
+	b := tx.Bucket(threadsBucket).Get([]byte(k.String()))
+	if b == nil {
+		return nil
+	}
+
+	var old Thread
+	if err := json.Unmarshal(b, &old); err != nil {
+		return err
+	}
+
+	return tx.Bucket(threadKeysBucket).Delete([]byte(old.ChannelID.String()))
+}
+
+func (s *Bolt) ThreadKey(ch discord.ChannelID) (Key, error) {
+	var k Key
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(threadKeysBucket).Get([]byte(ch.String()))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		var err error
+		k, err = ParseKey(string(b))
+		return err
+	})
+	return k, err
+}
+
+func (s *Bolt) Message(k MessageKey) (Message, error) {
+	var m Message
+	return m, s.get(messagesBucket, k.String(), &m)
+}
+
+func (s *Bolt) SetMessage(k MessageKey, m Message) error {
+	return s.put(messagesBucket, k.String(), m)
+}
+
+func (s *Bolt) DeleteMessage(k MessageKey) error {
+	return s.delete(messagesBucket, k.String())
+}
+
+func (s *Bolt) get(bucket []byte, k string, v any) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket).Get([]byte(k))
+		if b == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(b, v)
+	})
+}
+
+func (s *Bolt) put(bucket []byte, k string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(k), b)
+	})
+}
+
+func (s *Bolt) delete(bucket []byte, k string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete([]byte(k))
+	})
+}
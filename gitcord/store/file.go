@@ -0,0 +1,159 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/pkg/errors"
+)
+
+// File is a Store backed by a single JSON file. The whole file is rewritten
+// on every change, so it is best suited for small deployments. It is not safe
+// to share the same file between multiple processes.
+type File struct {
+	mu   sync.RWMutex
+	path string
+	data fileData
+}
+
+type fileData struct {
+	Threads  map[string]Thread  `json:"threads"`
+	Messages map[string]Message `json:"messages"`
+}
+
+var _ Store = (*File)(nil)
+
+// NewFile creates a new Store backed by the JSON file at path. The file is
+// created on the first write if it does not exist.
+func NewFile(path string) (*File, error) {
+	s := &File{
+		path: path,
+		data: fileData{
+			Threads:  make(map[string]Thread),
+			Messages: make(map[string]Message),
+		},
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, errors.Wrap(err, "failed to read store file")
+	}
+
+	if err := json.Unmarshal(b, &s.data); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse store file %q", path)
+	}
+
+	if s.data.Threads == nil {
+		s.data.Threads = make(map[string]Thread)
+	}
+	if s.data.Messages == nil {
+		s.data.Messages = make(map[string]Message)
+	}
+
+	return s, nil
+}
+
+func (s *File) Thread(k Key) (Thread, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.data.Threads[k.String()]
+	if !ok {
+		return Thread{}, ErrNotFound
+	}
+	return t, nil
+}
+
+func (s *File) SetThread(k Key, t Thread) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Threads[k.String()] = t
+	return s.save()
+}
+
+func (s *File) DeleteThread(k Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Threads[k.String()]; !ok {
+		return nil
+	}
+
+	delete(s.data.Threads, k.String())
+	return s.save()
+}
+
+func (s *File) ThreadKey(ch discord.ChannelID) (Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for k, t := range s.data.Threads {
+		if t.ChannelID == ch {
+			return ParseKey(k)
+		}
+	}
+	return Key{}, ErrNotFound
+}
+
+func (s *File) Message(k MessageKey) (Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m, ok := s.data.Messages[k.String()]
+	if !ok {
+		return Message{}, ErrNotFound
+	}
+	return m, nil
+}
+
+func (s *File) SetMessage(k MessageKey, m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Messages[k.String()] = m
+	return s.save()
+}
+
+func (s *File) DeleteMessage(k MessageKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Messages[k.String()]; !ok {
+		return nil
+	}
+
+	delete(s.data.Messages, k.String())
+	return s.save()
+}
+
+// save atomically writes the store to disk. s.mu must be held.
+func (s *File) save() error {
+	b, err := json.MarshalIndent(s.data, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary store file")
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to write store file")
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to write store file")
+	}
+
+	return errors.Wrap(os.Rename(f.Name(), s.path), "failed to replace store file")
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Memory is a Store that only lives as long as the process.
+type Memory struct {
+	mu       sync.RWMutex
+	threads  map[Key]Thread
+	messages map[MessageKey]Message
+}
+
+var _ Store = (*Memory)(nil)
+
+// NewMemory creates a new in-memory Store.
+func NewMemory() *Memory {
+	return &Memory{
+		threads:  make(map[Key]Thread),
+		messages: make(map[MessageKey]Message),
+	}
+}
+
+func (s *Memory) Thread(k Key) (Thread, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.threads[k]
+	if !ok {
+		return Thread{}, ErrNotFound
+	}
+	return t, nil
+}
+
+func (s *Memory) SetThread(k Key, t Thread) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.threads[k] = t
+	return nil
+}
+
+func (s *Memory) DeleteThread(k Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.threads, k)
+	return nil
+}
+
+func (s *Memory) ThreadKey(ch discord.ChannelID) (Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for k, t := range s.threads {
+		if t.ChannelID == ch {
+			return k, nil
+		}
+	}
+	return Key{}, ErrNotFound
+}
+
+func (s *Memory) Message(k MessageKey) (Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m, ok := s.messages[k]
+	if !ok {
+		return Message{}, ErrNotFound
+	}
+	return m, nil
+}
+
+func (s *Memory) SetMessage(k MessageKey, m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages[k] = m
+	return nil
+}
+
+func (s *Memory) DeleteMessage(k MessageKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.messages, k)
+	return nil
+}
@@ -0,0 +1,133 @@
+// Package store contains persistent mappings between GitHub issues, pull
+// requests and their comments and the Discord threads and messages created for
+// them.
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// ErrNotFound is returned when a key does not have a mapping.
+var ErrNotFound = errors.New("not found")
+
+// Key identifies an issue or pull request within a repository.
+type Key struct {
+	// Repo is the repository in owner/repo form.
+	Repo string
+	// Number is the issue or pull request number.
+	Number int
+}
+
+// String formats the key as owner/repo#number.
+func (k Key) String() string {
+	return fmt.Sprintf("%s#%d", k.Repo, k.Number)
+}
+
+// SplitRepo splits the repository of the key into its owner and name.
+func (k Key) SplitRepo() (owner, repo string, ok bool) {
+	return strings.Cut(k.Repo, "/")
+}
+
+// ParseKey parses a key formatted by Key.String.
+func ParseKey(s string) (Key, error) {
+	i := strings.LastIndexByte(s, '#')
+	if i == -1 {
+		return Key{}, fmt.Errorf("invalid key %q", s)
+	}
+
+	n, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return Key{}, fmt.Errorf("invalid key %q: %w", s, err)
+	}
+
+	return Key{Repo: s[:i], Number: n}, nil
+}
+
+// ThreadKind is the kind of GitHub object that a Discord thread mirrors.
+type ThreadKind string
+
+const (
+	// IssueThread is the kind of threads of issues and pull requests, and of
+	// threads recorded before kinds were.
+	IssueThread ThreadKind = ""
+	// DiscussionThread is the kind of threads of discussions, which share
+	// their numbers with issues and pull requests.
+	DiscussionThread ThreadKind = "discussion"
+)
+
+// Thread is the Discord thread of an issue, pull request or discussion.
+type Thread struct {
+	// ChannelID is the ID of the thread channel.
+	ChannelID discord.ChannelID `json:"channel_id"`
+	// MessageID is the ID of the initial message within the thread. It may be
+	// zero if the initial message is unknown.
+	MessageID discord.MessageID `json:"message_id,omitempty"`
+	// Kind is the kind of the thread.
+	Kind ThreadKind `json:"kind,omitempty"`
+}
+
+// MessageKind is the kind of GitHub object that a Discord message mirrors.
+type MessageKind string
+
+const (
+	IssueCommentMsg      MessageKind = "issue_comment"
+	ReviewMsg            MessageKind = "review"
+	ReviewCommentMsg     MessageKind = "review_comment"
+	ReviewThreadMsg      MessageKind = "review_thread"
+	ReleaseMsg           MessageKind = "release"
+	DiscussionCommentMsg MessageKind = "discussion_comment"
+	// CIStatusMsg is keyed by the ID of the pull request thread rather than
+	// by a GitHub object ID, since there is one per thread.
+	CIStatusMsg MessageKind = "ci_status"
+)
+
+// MessageKey identifies a GitHub object that is mirrored by a Discord message.
+// GitHub object IDs are only unique within their kind.
+type MessageKey struct {
+	Kind MessageKind
+	ID   int64
+}
+
+// String formats the key as kind/id.
+func (k MessageKey) String() string {
+	return fmt.Sprintf("%s/%d", k.Kind, k.ID)
+}
+
+// Message is a Discord message mirroring a GitHub object.
+type Message struct {
+	// ChannelID is the ID of the channel, usually a thread, containing the
+	// message.
+	ChannelID discord.ChannelID `json:"channel_id"`
+	// MessageID is the ID of the message.
+	MessageID discord.MessageID `json:"message_id"`
+	// Reply is true if the message was sent by a Discord user and posted to
+	// GitHub, rather than sent by gitcord.
+	Reply bool `json:"reply,omitempty"`
+}
+
+// Store maps issues and pull requests to their Discord threads, and GitHub
+// objects to the Discord messages mirroring them. All methods must be safe for
+// concurrent use.
+type Store interface {
+	// Thread returns the thread of k or ErrNotFound.
+	Thread(k Key) (Thread, error)
+	// SetThread sets the thread of k.
+	SetThread(k Key, t Thread) error
+	// DeleteThread deletes the thread of k, if any.
+	DeleteThread(k Key) error
+	// ThreadKey returns the key of the issue or pull request whose thread is
+	// ch, or ErrNotFound.
+	ThreadKey(ch discord.ChannelID) (Key, error)
+
+	// Message returns the message of k or ErrNotFound.
+	Message(k MessageKey) (Message, error)
+	// SetMessage sets the message of k.
+	SetMessage(k MessageKey, m Message) error
+	// DeleteMessage deletes the message of k, if any.
+	DeleteMessage(k MessageKey) error
+}
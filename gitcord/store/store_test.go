@@ -0,0 +1,184 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestStores(t *testing.T) {
+	type test struct {
+		name string
+		open func(t *testing.T, path string) Store
+	}
+
+	tests := []test{
+		{
+			name: "memory",
+			open: func(t *testing.T, path string) Store { return NewMemory() },
+		},
+		{
+			name: "file",
+			open: func(t *testing.T, path string) Store {
+				s, err := NewFile(path + ".json")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return s
+			},
+		},
+		{
+			name: "bolt",
+			open: func(t *testing.T, path string) Store {
+				s, err := NewBolt(path + ".db")
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { s.Close() })
+				return s
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := test.open(t, filepath.Join(t.TempDir(), "store"))
+
+			k1 := Key{Repo: "acmcsuf/a", Number: 12}
+			k2 := Key{Repo: "acmcsuf/b", Number: 12}
+
+			if _, err := s.Thread(k1); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+
+			if err := s.SetThread(k1, Thread{ChannelID: 1, MessageID: 2}); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.SetThread(k2, Thread{ChannelID: 3, Kind: DiscussionThread}); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := s.Thread(k1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != (Thread{ChannelID: 1, MessageID: 2}) {
+				t.Errorf("unexpected thread %+v", got)
+			}
+
+			got, err = s.Thread(k2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != (Thread{ChannelID: 3, Kind: DiscussionThread}) {
+				t.Errorf("unexpected thread %+v", got)
+			}
+
+			key, err := s.ThreadKey(3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key != k2 {
+				t.Errorf("unexpected key %v", key)
+			}
+
+			// Moving k2 to another thread forgets about the old one.
+			if err := s.SetThread(k2, Thread{ChannelID: 4}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.ThreadKey(3); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound for old thread, got %v", err)
+			}
+
+			if err := s.DeleteThread(k1); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Thread(k1); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound after delete, got %v", err)
+			}
+			if _, err := s.ThreadKey(1); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound for deleted thread, got %v", err)
+			}
+
+			// The same ID may be used by different kinds of objects.
+			m1 := MessageKey{Kind: IssueCommentMsg, ID: 42}
+			m2 := MessageKey{Kind: ReviewMsg, ID: 42}
+
+			if _, err := s.Message(m1); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+
+			if err := s.SetMessage(m1, Message{ChannelID: 1, MessageID: 2}); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.SetMessage(m2, Message{ChannelID: 1, MessageID: 3}); err != nil {
+				t.Fatal(err)
+			}
+
+			msg, err := s.Message(m1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if msg != (Message{ChannelID: 1, MessageID: 2}) {
+				t.Errorf("unexpected message %+v", msg)
+			}
+
+			if err := s.DeleteMessage(m1); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Message(m1); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound after delete, got %v", err)
+			}
+
+			msg, err = s.Message(m2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if msg != (Message{ChannelID: 1, MessageID: 3}) {
+				t.Errorf("unexpected message %+v", msg)
+			}
+		})
+	}
+}
+
+func TestFilePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	k := Key{Repo: "acmcsuf/a", Number: 1}
+
+	s, err := NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetThread(k, Thread{ChannelID: 5, MessageID: 6}); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Thread(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != (Thread{ChannelID: 5, MessageID: 6}) {
+		t.Errorf("unexpected thread %+v", got)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	k := Key{Repo: "acmcsuf/acmcsuf.com", Number: 420}
+
+	got, err := ParseKey(k.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != k {
+		t.Errorf("unexpected key %v, want %v", got, k)
+	}
+
+	if _, err := ParseKey("acmcsuf/acmcsuf.com"); err == nil {
+		t.Error("expected error for key without number")
+	}
+}
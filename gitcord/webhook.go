@@ -0,0 +1,173 @@
+package gitcord
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-github/v47/github"
+)
+
+// maxPayloadSize is the maximum size of a webhook delivery. GitHub caps
+// payloads at 25 MB.
+const maxPayloadSize = 25 << 20
+
+// WebhookHandler is an http.Handler that receives GitHub webhook deliveries,
+// verifies their X-Hub-Signature-256 HMAC and dispatches them into a Client.
+//
+// https://docs.github.com/en/developers/webhooks-and-events/webhooks/securing-your-webhooks
+type WebhookHandler struct {
+	ctx    context.Context
+	secret []byte
+	logger *log.Logger
+	// doEvent is Client.DoEvent. It is a field so that tests can stub it out.
+	doEvent func(ctx context.Context, ev *github.Event) error
+	queue   deliveryQueue
+}
+
+// NewWebhookHandler creates a new WebhookHandler that dispatches verified
+// deliveries into c. Deliveries are handled in the background using ctx, since
+// GitHub expects a response within 10 seconds. Deliveries of the same
+// repository are handled one at a time in the order they were received, so
+// that e.g. an issue being opened and labeled doesn't race to create its
+// thread.
+func NewWebhookHandler(ctx context.Context, c *Client, secret []byte) *WebhookHandler {
+	logger := c.client.config.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &WebhookHandler{
+		ctx:    ctx,
+		secret: secret,
+		logger: logger,
+		doEvent: func(ctx context.Context, ev *github.Event) error {
+			return c.WithContext(ctx).DoEvent(ev)
+		},
+	}
+}
+
+func (h *WebhookHandler) logln(v ...any) {
+	prefixed := []any{"webhook:"}
+	prefixed = append(prefixed, v...)
+	h.logger.Println(prefixed...)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if len(h.secret) == 0 {
+		http.Error(w, "webhook secret not configured", http.StatusInternalServerError)
+		return
+	}
+
+	signature := r.Header.Get(github.SHA256SignatureHeader)
+	if signature == "" {
+		http.Error(w, "missing "+github.SHA256SignatureHeader+" header", http.StatusUnauthorized)
+		return
+	}
+
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, "invalid Content-Type", http.StatusBadRequest)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxPayloadSize)
+	payload, err := github.ValidatePayloadFromBody(contentType, body, signature, h.secret)
+	if err != nil {
+		h.logln("rejected delivery", github.DeliveryID(r)+":", err)
+		http.Error(w, "invalid payload signature", http.StatusUnauthorized)
+		return
+	}
+
+	name := github.WebHookType(r)
+	if name == "" {
+		http.Error(w, "missing "+github.EventTypeHeader+" header", http.StatusBadRequest)
+		return
+	}
+
+	if name == "ping" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	evType := EventType(name)
+	raw := json.RawMessage(payload)
+	ev := &github.Event{
+		Type:       &evType,
+		RawPayload: &raw,
+	}
+
+	delivery := github.DeliveryID(r)
+	h.queue.push(deliveryRepo(payload), func() {
+		if err := h.doEvent(h.ctx, ev); err != nil {
+			h.logln("delivery", delivery+":", err)
+		}
+	})
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// deliveryRepo returns the full name of the repository of a webhook payload,
+// or an empty string if it has none.
+func deliveryRepo(payload []byte) string {
+	var v struct {
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	json.Unmarshal(payload, &v)
+	return v.Repository.FullName
+}
+
+// deliveryQueue runs jobs in the background, one at a time per key and in the
+// order they were pushed. Jobs of different keys run concurrently. The zero
+// value is ready to use.
+type deliveryQueue struct {
+	mu sync.Mutex
+	// pending holds the jobs waiting behind the running one of each key. A key
+	// is present for as long as one of its jobs is running.
+	pending map[string][]func()
+}
+
+func (q *deliveryQueue) push(key string, job func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pending == nil {
+		q.pending = make(map[string][]func())
+	}
+
+	if jobs, ok := q.pending[key]; ok {
+		q.pending[key] = append(jobs, job)
+		return
+	}
+
+	q.pending[key] = nil
+	go q.run(key, job)
+}
+
+func (q *deliveryQueue) run(key string, job func()) {
+	for {
+		job()
+
+		q.mu.Lock()
+		jobs := q.pending[key]
+		if len(jobs) == 0 {
+			delete(q.pending, key)
+			q.mu.Unlock()
+			return
+		}
+		job, q.pending[key] = jobs[0], jobs[1:]
+		q.mu.Unlock()
+	}
+}
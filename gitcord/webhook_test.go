@@ -0,0 +1,175 @@
+package gitcord
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v47/github"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	const secret = "It's a Secret to Everybody"
+	const payload = `{"action":"opened","issue":{"number":1}}`
+
+	sign := func(key, body string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	type test struct {
+		name      string
+		method    string
+		event     string
+		signature string
+		status    int
+		eventType string // expected dispatched event type, if any
+	}
+
+	tests := []test{
+		{
+			name:      "valid",
+			method:    http.MethodPost,
+			event:     "issues",
+			signature: sign(secret, payload),
+			status:    http.StatusAccepted,
+			eventType: "IssuesEvent",
+		},
+		{
+			name:      "snake case event",
+			method:    http.MethodPost,
+			event:     "pull_request_review_comment",
+			signature: sign(secret, payload),
+			status:    http.StatusAccepted,
+			eventType: "PullRequestReviewCommentEvent",
+		},
+		{
+			name:      "ping",
+			method:    http.MethodPost,
+			event:     "ping",
+			signature: sign(secret, payload),
+			status:    http.StatusOK,
+		},
+		{
+			name:      "wrong secret",
+			method:    http.MethodPost,
+			event:     "issues",
+			signature: sign("wrong", payload),
+			status:    http.StatusUnauthorized,
+		},
+		{
+			name:   "missing signature",
+			method: http.MethodPost,
+			event:  "issues",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:      "missing event",
+			method:    http.MethodPost,
+			signature: sign(secret, payload),
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:   "wrong method",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dispatched := make(chan *github.Event, 1)
+			h := &WebhookHandler{
+				ctx:    context.Background(),
+				secret: []byte(secret),
+				logger: log.New(io.Discard, "", 0),
+				doEvent: func(ctx context.Context, ev *github.Event) error {
+					dispatched <- ev
+					return nil
+				},
+			}
+
+			r := httptest.NewRequest(test.method, "/", strings.NewReader(payload))
+			r.Header.Set("Content-Type", "application/json")
+			if test.event != "" {
+				r.Header.Set(github.EventTypeHeader, test.event)
+			}
+			if test.signature != "" {
+				r.Header.Set(github.SHA256SignatureHeader, test.signature)
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != test.status {
+				t.Fatalf("unexpected status code %d, want %d", w.Code, test.status)
+			}
+
+			if test.eventType == "" {
+				select {
+				case ev := <-dispatched:
+					t.Fatalf("unexpected dispatch of %q", ev.GetType())
+				default:
+				}
+				return
+			}
+
+			select {
+			case ev := <-dispatched:
+				if ev.GetType() != test.eventType {
+					t.Errorf("unexpected event type %q, want %q", ev.GetType(), test.eventType)
+				}
+				if string(*ev.RawPayload) != payload {
+					t.Errorf("unexpected payload %q", *ev.RawPayload)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("event was not dispatched")
+			}
+		})
+	}
+}
+
+func TestDeliveryQueue(t *testing.T) {
+	var q deliveryQueue
+
+	release := make(chan struct{})
+	done := make(chan string, 3)
+
+	q.push("o/r", func() {
+		<-release
+		done <- "opened"
+	})
+	q.push("o/r", func() { done <- "labeled" })
+	q.push("o/other", func() { done <- "other" })
+
+	// Other repositories aren't held up by a running delivery.
+	select {
+	case got := <-done:
+		if got != "other" {
+			t.Fatalf("%q ran before the delivery before it", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("delivery of another repository was not run")
+	}
+
+	close(release)
+	for _, want := range []string{"opened", "labeled"} {
+		select {
+		case got := <-done:
+			if got != want {
+				t.Fatalf("unexpected delivery %q, want %q", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("delivery %q was not run", want)
+		}
+	}
+}
@@ -5,13 +5,18 @@ go 1.19
 require (
 	github.com/google/go-github/v47 v47.1.0
 	github.com/yuin/goldmark v1.3.2
+	go.etcd.io/bbolt v1.3.6
 	go4.org v0.0.0-20201209231011-d4a079459e60
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/cpuguy83/go-md2man/v2 v2.0.2 // indirect
+	github.com/gorilla/websocket v1.4.2 // indirect
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
+	golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
+	golang.org/x/time v0.0.0-20210723032227-1f47c861a9ac // indirect
 )
 
 require (
@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
-	"regexp"
+	"os/signal"
+	"path"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/ethanthatonekid/gitcord/gitcord"
+	"github.com/ethanthatonekid/gitcord/gitcord/store"
 	"github.com/joho/godotenv"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
@@ -28,6 +36,10 @@ func main() {
 type App struct {
 	*cli.App
 	client *gitcord.Client
+	store  store.Store
+	// file is the configuration file, if any.
+	file          *fileConfig
+	webhookSecret string
 }
 
 func NewApp() *App {
@@ -42,31 +54,39 @@ func NewApp() *App {
 				Aliases: []string{"f"},
 				Usage:   "force open threads",
 			},
+			&cli.BoolFlag{
+				Name:    "create-forum-tags",
+				Usage:   "create forum tags for labels without one",
+				EnvVars: []string{"GITCORD_CREATE_FORUM_TAGS"},
+			},
+			&cli.BoolFlag{
+				Name:    "defer-draft-threads",
+				Usage:   "open threads of draft pull requests once they are ready for review",
+				EnvVars: []string{"GITCORD_DEFER_DRAFT_THREADS"},
+			},
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Usage:   "read the configuration from a YAML `FILE` instead of the environment",
+				EnvVars: []string{"GITCORD_CONFIG"},
+			},
+			&cli.StringFlag{
+				Name:    "store",
+				Usage:   "persist the issue-to-thread mapping in `json:PATH` or `bolt:PATH`",
+				EnvVars: []string{"GITCORD_STORE"},
+			},
 		},
-		Action: func(ctx *cli.Context) error {
-			channelID, err := discord.ParseSnowflake(os.Getenv("DISCORD_CHANNEL_ID"))
-			if err != nil {
-				return errors.Wrap(err, "failed to parse Discord channel ID")
+		After: func(ctx *cli.Context) error {
+			if closer, ok := app.store.(io.Closer); ok {
+				return closer.Close()
 			}
-
-			colors, err := parseEnvColors()
-			if err != nil {
+			return nil
+		},
+		Action: func(ctx *cli.Context) error {
+			if err := app.initClient(ctx); err != nil {
 				return err
 			}
 
-			config := gitcord.Config{
-				GitHubOAuth: oauth2.StaticTokenSource(&oauth2.Token{
-					AccessToken: os.Getenv("GITHUB_TOKEN"),
-				}),
-				DiscordToken:     "Bot " + os.Getenv("DISCORD_TOKEN"),
-				DiscordChannelID: discord.ChannelID(channelID),
-				ColorScheme:      colors,
-				ForceOpen:        ctx.Bool("force"),
-				Logger:           log.Default(),
-			}
-
-			app.client = gitcord.NewClient(config).WithContext(ctx.Context)
-
 			eventIDStr := ctx.Args().First()
 			switch eventIDStr {
 			case "":
@@ -80,7 +100,7 @@ func NewApp() *App {
 					return errors.New("no github event payload provided")
 				}
 
-				return app.client.DoEventPayload(pascalFromSnake(eventName)+"Event", eventPayload)
+				return app.client.DoEventPayload(gitcord.EventType(eventName), eventPayload)
 
 			default:
 				eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
@@ -91,22 +111,461 @@ func NewApp() *App {
 				return app.client.DoEventID(eventID)
 			}
 		},
+		Commands: []*cli.Command{
+			{
+				Name:  "serve",
+				Usage: "listen for GitHub webhook deliveries over HTTP",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "addr",
+						Usage:   "address to listen on",
+						Value:   ":8080",
+						EnvVars: []string{"GITCORD_ADDR"},
+					},
+					&cli.BoolFlag{
+						Name:    "gateway",
+						Usage:   "connect to the Discord gateway for thread replies and slash commands",
+						EnvVars: []string{"GITCORD_GATEWAY"},
+					},
+				},
+				Action: app.serve,
+			},
+			{
+				Name:  "config",
+				Usage: "manage the configuration file",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "validate",
+						Usage:     "report unknown keys and bad values in a configuration file",
+						ArgsUsage: "[FILE]",
+						Action:    validateConfig,
+					},
+				},
+			},
+		},
 	}
 
 	return app
 }
 
-// colorEnvMap maps environment variable prefixes to their respective color
-// scheme key.
-var colorEnvMap = map[string]gitcord.ColorSchemeKey{
-	"GITCORD_COLOR_ISSUE_OPENED": gitcord.IssueOpened,
+// initClient initializes app.client from the configuration file given by
+// --config, or else from the environment. Flags override either.
+func (app *App) initClient(ctx *cli.Context) error {
+	var config gitcord.Config
+	var storeSpec string
+	var err error
+
+	if path := ctx.String("config"); path != "" {
+		app.file, err = loadConfigFile(path)
+		if err != nil {
+			return err
+		}
+
+		config, err = app.file.gitcordConfig()
+		storeSpec = string(app.file.Store)
+		app.webhookSecret = string(app.file.GitHub.WebhookSecret)
+	} else {
+		config, err = envConfig()
+		app.webhookSecret = os.Getenv("GITHUB_WEBHOOK_SECRET")
+	}
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsSet("store") || storeSpec == "" {
+		storeSpec = ctx.String("store")
+	}
+
+	app.store, err = openStore(storeSpec)
+	if err != nil {
+		return err
+	}
+
+	config.Store = app.store
+	config.ForceOpen = config.ForceOpen || ctx.Bool("force")
+	config.CreateForumTags = config.CreateForumTags || ctx.Bool("create-forum-tags")
+	config.DeferDraftThreads = config.DeferDraftThreads || ctx.Bool("defer-draft-threads")
+	config.Logger = log.Default()
+
+	app.client = gitcord.NewClient(config).WithContext(ctx.Context)
+	return nil
+}
+
+// envConfig reads the configuration from the environment.
+func envConfig() (gitcord.Config, error) {
+	routes, err := parseRoutes(os.Getenv("GITCORD_ROUTES"))
+	if err != nil {
+		return gitcord.Config{}, err
+	}
+
+	rules, err := parseRules(os.Getenv("GITCORD_RULES"))
+	if err != nil {
+		return gitcord.Config{}, err
+	}
+
+	// With a routing table, the channel is only the fallback for repositories
+	// without a route.
+	var channelID discord.Snowflake
+	if s := os.Getenv("DISCORD_CHANNEL_ID"); s != "" || len(routes) == 0 {
+		channelID, err = discord.ParseSnowflake(s)
+		if err != nil {
+			return gitcord.Config{}, errors.Wrap(err, "failed to parse Discord channel ID")
+		}
+	}
+
+	var channels gitcord.FeedChannels
+	if err := parseChannelEnvs(map[string]*discord.ChannelID{
+		"GITCORD_PUSHES_CHANNEL_ID":      &channels.Pushes,
+		"GITCORD_RELEASES_CHANNEL_ID":    &channels.Releases,
+		"GITCORD_DEPLOYMENTS_CHANNEL_ID": &channels.Deployments,
+		"GITCORD_REFS_CHANNEL_ID":        &channels.Refs,
+		"GITCORD_SECURITY_CHANNEL_ID":    &channels.Security,
+		"GITCORD_ACTIVITY_CHANNEL_ID":    &channels.Activity,
+		"GITCORD_MILESTONES_CHANNEL_ID":  &channels.Milestones,
+		"GITCORD_LABELS_CHANNEL_ID":      &channels.Labels,
+		"GITCORD_COMMITS_CHANNEL_ID":     &channels.Commits,
+		"GITCORD_WIKI_CHANNEL_ID":        &channels.Wiki,
+	}); err != nil {
+		return gitcord.Config{}, err
+	}
+
+	if s := os.Getenv("GITCORD_CROSSPOST_RELEASES"); s != "" {
+		channels.CrosspostReleases, err = strconv.ParseBool(s)
+		if err != nil {
+			return gitcord.Config{}, errors.Wrap(err, "$GITCORD_CROSSPOST_RELEASES: invalid boolean")
+		}
+	}
+
+	if s := os.Getenv("GITCORD_WIKI_DIFFS"); s != "" {
+		channels.WikiDiffs, err = strconv.ParseBool(s)
+		if err != nil {
+			return gitcord.Config{}, errors.Wrap(err, "$GITCORD_WIKI_DIFFS: invalid boolean")
+		}
+	}
+
+	if s := os.Getenv("GITCORD_ACTIVITY_BATCH"); s != "" {
+		channels.ActivityBatch, err = time.ParseDuration(s)
+		if err != nil {
+			return gitcord.Config{}, errors.Wrap(err, "$GITCORD_ACTIVITY_BATCH: invalid duration")
+		}
+	}
+
+	var refs gitcord.RefFilter
+	if refs.Branches, err = parseRefPatterns("GITCORD_REF_BRANCHES"); err != nil {
+		return gitcord.Config{}, err
+	}
+	if refs.Tags, err = parseRefPatterns("GITCORD_REF_TAGS"); err != nil {
+		return gitcord.Config{}, err
+	}
+
+	colors, err := parseEnvColors()
+	if err != nil {
+		return gitcord.Config{}, err
+	}
+
+	commandRoles, err := parseCommandRoles(os.Getenv("GITCORD_COMMAND_ROLES"))
+	if err != nil {
+		return gitcord.Config{}, err
+	}
+
+	githubApp, err := parseGitHubApp()
+	if err != nil {
+		return gitcord.Config{}, err
+	}
+
+	return gitcord.Config{
+		GitHubOAuth: oauth2.StaticTokenSource(&oauth2.Token{
+			AccessToken: os.Getenv("GITHUB_TOKEN"),
+		}),
+		GitHubApp:          githubApp,
+		GitHubRepo:         os.Getenv("GITHUB_REPO"),
+		DiscordToken:       "Bot " + os.Getenv("DISCORD_TOKEN"),
+		DiscordChannelID:   discord.ChannelID(channelID),
+		Routes:             routes,
+		Rules:              rules,
+		Channels:           channels,
+		Refs:               refs,
+		ColorScheme:        colors,
+		CommandPermissions: commandRoles,
+	}, nil
+}
+
+// parseGitHubApp parses the GitHub App configuration from the environment. It
+// returns nil if $GITHUB_APP_ID is not set, in which case $GITHUB_TOKEN is
+// used instead. The private key is given either inline in
+// $GITHUB_APP_PRIVATE_KEY or as a path in $GITHUB_APP_PRIVATE_KEY_FILE.
+func parseGitHubApp() (*gitcord.GitHubAppConfig, error) {
+	appIDStr := os.Getenv("GITHUB_APP_ID")
+	if appIDStr == "" {
+		return nil, nil
+	}
+
+	appID, err := strconv.ParseInt(appIDStr, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse GitHub App ID")
+	}
+
+	key := []byte(os.Getenv("GITHUB_APP_PRIVATE_KEY"))
+	if path := os.Getenv("GITHUB_APP_PRIVATE_KEY_FILE"); path != "" {
+		key, err = os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read GitHub App private key")
+		}
+	}
+	if len(key) == 0 {
+		return nil, errors.New("no github app private key provided")
+	}
+
+	var installationID int64
+	if s := os.Getenv("GITHUB_APP_INSTALLATION_ID"); s != "" {
+		installationID, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse GitHub App installation ID")
+		}
+	}
+
+	return &gitcord.GitHubAppConfig{
+		AppID:          appID,
+		PrivateKey:     key,
+		InstallationID: installationID,
+	}, nil
+}
+
+// serve runs the webhook HTTP server until interrupted.
+func (app *App) serve(ctx *cli.Context) error {
+	if err := app.initClient(ctx); err != nil {
+		return err
+	}
+
+	if app.webhookSecret == "" {
+		return errors.New("no github webhook secret provided")
+	}
+
+	addr := ctx.String("addr")
+	gateway := ctx.Bool("gateway")
+	if app.file != nil {
+		if !ctx.IsSet("addr") && app.file.Server.Addr != "" {
+			addr = string(app.file.Server.Addr)
+		}
+		gateway = gateway || app.file.Server.Gateway
+	}
+
+	sigctx, cancel := signal.NotifyContext(ctx.Context, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           gitcord.NewWebhookHandler(sigctx, app.client, []byte(app.webhookSecret)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 2)
+	go func() {
+		log.Println("listening for webhook deliveries on", srv.Addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	if gateway {
+		gw := gitcord.NewGateway(app.client)
+		go func() {
+			log.Println("connecting to the Discord gateway")
+			if err := gw.Run(sigctx); err != nil && sigctx.Err() == nil {
+				errCh <- errors.Wrap(err, "gateway")
+			}
+		}()
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-sigctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// validateConfig validates the configuration file given as the argument or by
+// --config.
+func validateConfig(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if path == "" {
+		path = ctx.String("config")
+	}
+	if path == "" {
+		return errors.New("no config file provided")
+	}
+
+	if _, err := loadConfigFile(path); err != nil {
+		var cfgErr *configError
+		if !errors.As(err, &cfgErr) {
+			return err
+		}
+
+		for _, problem := range cfgErr.problems {
+			fmt.Fprintln(ctx.App.ErrWriter, problem)
+		}
+		return cli.Exit("", 1)
+	}
+
+	fmt.Fprintf(ctx.App.Writer, "%s: OK\n", path)
+	return nil
 }
 
+// openStore opens the store described by spec, which is either empty for an
+// in-memory store or one of json:PATH and bolt:PATH.
+func openStore(spec string) (store.Store, error) {
+	if spec == "" {
+		return store.NewMemory(), nil
+	}
+
+	kind, path, ok := strings.Cut(spec, ":")
+	if !ok || path == "" {
+		return nil, fmt.Errorf("invalid store %q, must be json:PATH or bolt:PATH", spec)
+	}
+
+	switch kind {
+	case "json":
+		return store.NewFile(path)
+	case "bolt":
+		return store.NewBolt(path)
+	default:
+		return nil, fmt.Errorf("unknown store type %q", kind)
+	}
+}
+
+// parseCommandRoles parses slash command permissions in the form of
+// "action=roleID,roleID;action=roleID", where action may be "*" for all
+// actions.
+func parseCommandRoles(val string) (gitcord.CommandPermissions, error) {
+	if val == "" {
+		return nil, nil
+	}
+
+	perms := gitcord.CommandPermissions{}
+
+	for _, entry := range strings.Split(val, ";") {
+		action, roles, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("$GITCORD_COMMAND_ROLES: invalid entry %q, must be action=roleID,...", entry)
+		}
+
+		for _, role := range strings.Split(roles, ",") {
+			id, err := discord.ParseSnowflake(strings.TrimSpace(role))
+			if err != nil {
+				return nil, errors.Wrapf(err, "$GITCORD_COMMAND_ROLES: invalid role ID %q", role)
+			}
+
+			a := gitcord.CommandAction(action)
+			perms[a] = append(perms[a], discord.RoleID(id))
+		}
+	}
+
+	return perms, nil
+}
+
+// parseChannelEnvs parses the channel IDs in the environment variables that
+// dsts are keyed by. Unset environment variables leave their channel unset.
+func parseChannelEnvs(dsts map[string]*discord.ChannelID) error {
+	for env, dst := range dsts {
+		val := os.Getenv(env)
+		if val == "" {
+			continue
+		}
+
+		id, err := discord.ParseSnowflake(val)
+		if err != nil {
+			return errors.Wrapf(err, "$%s: invalid channel ID", env)
+		}
+
+		*dst = discord.ChannelID(id)
+	}
+
+	return nil
+}
+
+// parseRoutes parses a routing table in the form of
+// "owner/repo=channelID;owner/*=channelID".
+func parseRoutes(val string) (gitcord.Routes, error) {
+	if val == "" {
+		return nil, nil
+	}
+
+	var routes gitcord.Routes
+
+	for _, entry := range strings.Split(val, ";") {
+		repo, channel, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("$GITCORD_ROUTES: invalid entry %q, must be owner/repo=channelID", entry)
+		}
+
+		if _, err := path.Match(repo, ""); err != nil {
+			return nil, errors.Wrapf(err, "$GITCORD_ROUTES: invalid repository pattern %q", repo)
+		}
+
+		id, err := discord.ParseSnowflake(strings.TrimSpace(channel))
+		if err != nil {
+			return nil, errors.Wrapf(err, "$GITCORD_ROUTES: invalid channel ID %q", channel)
+		}
+
+		routes = append(routes, gitcord.Route{Repo: repo, ChannelID: discord.ChannelID(id)})
+	}
+
+	return routes, nil
+}
+
+// parseRefPatterns parses comma-separated ref name patterns from the
+// environment variable env.
+func parseRefPatterns(env string) ([]string, error) {
+	val := os.Getenv(env)
+	if val == "" {
+		return nil, nil
+	}
+
+	patterns := strings.Split(val, ",")
+	for i, pattern := range patterns {
+		patterns[i] = strings.TrimSpace(pattern)
+		if _, err := path.Match(patterns[i], ""); err != nil {
+			return nil, errors.Wrapf(err, "$%s: invalid pattern %q", env, pattern)
+		}
+	}
+
+	return patterns, nil
+}
+
+// parseRules parses rules given as a JSON array. Refer to gitcord.Rule for the
+// fields of each rule.
+func parseRules(val string) (gitcord.Rules, error) {
+	if val == "" {
+		return nil, nil
+	}
+
+	var rules gitcord.Rules
+	if err := json.Unmarshal([]byte(val), &rules); err != nil {
+		return nil, errors.Wrap(err, "$GITCORD_RULES: invalid rules")
+	}
+
+	return rules, nil
+}
+
+// parseEnvColors parses the color scheme from environment variables named
+// after each color scheme key, e.g. $GITCORD_COLOR_ISSUE_OPENED_SUCCESS and
+// $GITCORD_COLOR_ISSUE_OPENED_ERROR.
 func parseEnvColors() (gitcord.ColorScheme, error) {
 	newScheme := gitcord.ColorScheme{}
 
-	for env, schemeKey := range colorEnvMap {
-		colors := gitcord.DefaultStatusColors
+	for schemeKey := range gitcord.DefaultColorScheme {
+		if schemeKey == gitcord.UnknownColorSchemeKey {
+			continue
+		}
+
+		env := "GITCORD_COLOR_" + strings.ToUpper(schemeKey.String())
+		if os.Getenv(env+"_SUCCESS") == "" && os.Getenv(env+"_ERROR") == "" {
+			continue
+		}
+
+		// Colors not given keep the key's default.
+		colors := gitcord.DefaultColorScheme[schemeKey]
 		if err := parseColorEnv(env+"_SUCCESS", &colors.Success); err != nil {
 			return nil, err
 		}
@@ -127,24 +586,11 @@ func parseColorEnv(env string, dst *discord.Color) error {
 		return nil
 	}
 
-	if !strings.HasPrefix(val, "#") {
-		return fmt.Errorf("$%s: invalid color must be of format #XXXXXX", env)
-	}
-
-	c, err := strconv.ParseInt(strings.TrimPrefix(val, "#"), 10, 32)
+	c, err := parseColor(val)
 	if err != nil {
 		return errors.Wrapf(err, "$%s: invalid color", env)
 	}
 
-	*dst = discord.Color(c)
+	*dst = c
 	return nil
-
-}
-
-var pascalFromSnakeRe = regexp.MustCompile(`(?m)(^|_)[a-z]`)
-
-func pascalFromSnake(str string) string {
-	return pascalFromSnakeRe.ReplaceAllStringFunc(str, func(s string) string {
-		return strings.ToUpper(strings.TrimPrefix(s, "_"))
-	})
 }